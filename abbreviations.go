@@ -0,0 +1,240 @@
+package epoch
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnknownAbbreviation   = fmt.Errorf("unknown timezone abbreviation")
+	ErrAmbiguousAbbreviation = fmt.Errorf("ambiguous timezone abbreviation")
+)
+
+// ZoneAbbreviation is one of the meanings of a timezone abbreviation, e.g. IST = India Standard Time (+05:30)
+type ZoneAbbreviation struct {
+	Abbreviation string `json:"abbreviation"`
+	// Name is the full name of the zone, e.g. "India Standard Time"
+	Name string `json:"name"`
+	// Offset is the offset in seconds east of UTC
+	Offset int `json:"offset"`
+}
+
+// Location returns a fixed zone named after the abbreviation
+func (z ZoneAbbreviation) Location() *time.Location {
+	return time.FixedZone(z.Abbreviation, z.Offset)
+}
+
+// AbbreviationResolution describes how an abbreviation found in a parsed string was resolved
+type AbbreviationResolution struct {
+	ZoneAbbreviation
+	// Ambiguous is true if the abbreviation has several meanings and the preferred one was chosen
+	Ambiguous bool `json:"ambiguous"`
+	// Candidates are names of all known meanings of the abbreviation
+	Candidates []string `json:"candidates,omitempty"`
+}
+
+func zoneAbbr(abbr, name string, hours, minutes int) ZoneAbbreviation {
+	offset := hours*3600 + minutes*60
+	if hours < 0 {
+		offset = hours*3600 - minutes*60
+	}
+	return ZoneAbbreviation{Abbreviation: abbr, Name: name, Offset: offset}
+}
+
+// defaultZoneAbbreviations is a curated list of common abbreviations.
+// The first meaning of an ambiguous abbreviation is the preferred one by default.
+var defaultZoneAbbreviations = []ZoneAbbreviation{
+	zoneAbbr("UTC", "Coordinated Universal Time", 0, 0),
+	zoneAbbr("GMT", "Greenwich Mean Time", 0, 0),
+
+	// North America
+	zoneAbbr("EST", "Eastern Standard Time", -5, 0),
+	zoneAbbr("EDT", "Eastern Daylight Time", -4, 0),
+	zoneAbbr("CST", "Central Standard Time", -6, 0),
+	zoneAbbr("CST", "China Standard Time", 8, 0),
+	zoneAbbr("CST", "Cuba Standard Time", -5, 0),
+	zoneAbbr("CDT", "Central Daylight Time", -5, 0),
+	zoneAbbr("CDT", "Cuba Daylight Time", -4, 0),
+	zoneAbbr("MST", "Mountain Standard Time", -7, 0),
+	zoneAbbr("MDT", "Mountain Daylight Time", -6, 0),
+	zoneAbbr("PST", "Pacific Standard Time", -8, 0),
+	zoneAbbr("PDT", "Pacific Daylight Time", -7, 0),
+	zoneAbbr("AKST", "Alaska Standard Time", -9, 0),
+	zoneAbbr("AKDT", "Alaska Daylight Time", -8, 0),
+	zoneAbbr("HST", "Hawaii-Aleutian Standard Time", -10, 0),
+	zoneAbbr("AST", "Atlantic Standard Time", -4, 0),
+	zoneAbbr("AST", "Arabia Standard Time", 3, 0),
+	zoneAbbr("ADT", "Atlantic Daylight Time", -3, 0),
+	zoneAbbr("NST", "Newfoundland Standard Time", -3, 30),
+	zoneAbbr("NDT", "Newfoundland Daylight Time", -2, 30),
+
+	// South America
+	zoneAbbr("BRT", "Brasilia Time", -3, 0),
+	zoneAbbr("ART", "Argentina Time", -3, 0),
+
+	// Europe
+	zoneAbbr("WET", "Western European Time", 0, 0),
+	zoneAbbr("WEST", "Western European Summer Time", 1, 0),
+	zoneAbbr("BST", "British Summer Time", 1, 0),
+	zoneAbbr("BST", "Bangladesh Standard Time", 6, 0),
+	zoneAbbr("IST", "India Standard Time", 5, 30),
+	zoneAbbr("IST", "Irish Standard Time", 1, 0),
+	zoneAbbr("IST", "Israel Standard Time", 2, 0),
+	zoneAbbr("CET", "Central European Time", 1, 0),
+	zoneAbbr("CEST", "Central European Summer Time", 2, 0),
+	zoneAbbr("EET", "Eastern European Time", 2, 0),
+	zoneAbbr("EEST", "Eastern European Summer Time", 3, 0),
+	zoneAbbr("MSK", "Moscow Standard Time", 3, 0),
+	zoneAbbr("IDT", "Israel Daylight Time", 3, 0),
+
+	// Africa
+	zoneAbbr("WAT", "West Africa Time", 1, 0),
+	zoneAbbr("CAT", "Central Africa Time", 2, 0),
+	zoneAbbr("EAT", "East Africa Time", 3, 0),
+	zoneAbbr("SAST", "South Africa Standard Time", 2, 0),
+
+	// Asia
+	zoneAbbr("PKT", "Pakistan Standard Time", 5, 0),
+	zoneAbbr("NPT", "Nepal Time", 5, 45),
+	zoneAbbr("ICT", "Indochina Time", 7, 0),
+	zoneAbbr("WIB", "Western Indonesia Time", 7, 0),
+	zoneAbbr("SGT", "Singapore Time", 8, 0),
+	zoneAbbr("HKT", "Hong Kong Time", 8, 0),
+	zoneAbbr("JST", "Japan Standard Time", 9, 0),
+	zoneAbbr("KST", "Korea Standard Time", 9, 0),
+
+	// Oceania
+	zoneAbbr("AWST", "Australian Western Standard Time", 8, 0),
+	zoneAbbr("ACST", "Australian Central Standard Time", 9, 30),
+	zoneAbbr("ACDT", "Australian Central Daylight Time", 10, 30),
+	zoneAbbr("AEST", "Australian Eastern Standard Time", 10, 0),
+	zoneAbbr("AEDT", "Australian Eastern Daylight Time", 11, 0),
+	zoneAbbr("NZST", "New Zealand Standard Time", 12, 0),
+	zoneAbbr("NZDT", "New Zealand Daylight Time", 13, 0),
+}
+
+// AbbreviationResolver maps timezone abbreviations (e.g. "PST", "CEST", "IST") to offsets.
+// Ambiguous abbreviations are resolved by preference, which can be configured (see Prefer).
+// It's safe for concurrent use.
+type AbbreviationResolver struct {
+	mu          sync.RWMutex
+	table       map[string][]ZoneAbbreviation
+	preferences map[string]string
+	strict      bool
+}
+
+// DefaultAbbreviationResolver is used by parsers unless another resolver is set
+var DefaultAbbreviationResolver = NewAbbreviationResolver()
+
+// NewAbbreviationResolver returns a resolver with the curated table of common abbreviations
+func NewAbbreviationResolver() *AbbreviationResolver {
+	r := &AbbreviationResolver{
+		table:       make(map[string][]ZoneAbbreviation),
+		preferences: make(map[string]string),
+	}
+	for _, z := range defaultZoneAbbreviations {
+		r.Add(z)
+	}
+	return r
+}
+
+// Add adds a meaning of an abbreviation. The first added meaning is the preferred one by default
+func (r *AbbreviationResolver) Add(z ZoneAbbreviation) *AbbreviationResolver {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	abbr := strings.ToUpper(z.Abbreviation)
+	z.Abbreviation = abbr
+	r.table[abbr] = append(r.table[abbr], z)
+	return r
+}
+
+// Prefer sets the meaning (by its name, e.g. "Israel Standard Time") used for an ambiguous abbreviation
+func (r *AbbreviationResolver) Prefer(abbr, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	abbr = strings.ToUpper(abbr)
+	for _, z := range r.table[abbr] {
+		if z.Name == name {
+			r.preferences[abbr] = name
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s (%s)", ErrUnknownAbbreviation, abbr, name)
+}
+
+// SetStrict makes the resolver fail on ambiguous abbreviations without an explicit preference
+func (r *AbbreviationResolver) SetStrict(strict bool) *AbbreviationResolver {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.strict = strict
+	return r
+}
+
+// Candidates returns all known meanings of the abbreviation
+func (r *AbbreviationResolver) Candidates(abbr string) []ZoneAbbreviation {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]ZoneAbbreviation(nil), r.table[strings.ToUpper(abbr)]...)
+}
+
+// IsAmbiguous returns true if the abbreviation has several meanings
+func (r *AbbreviationResolver) IsAmbiguous(abbr string) bool {
+	return len(r.Candidates(abbr)) > 1
+}
+
+// Resolve returns the meaning of the abbreviation: the only one, the preferred one or the first one
+func (r *AbbreviationResolver) Resolve(abbr string) (*AbbreviationResolution, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	abbr = strings.ToUpper(abbr)
+	candidates := r.table[abbr]
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAbbreviation, abbr)
+	}
+
+	res := &AbbreviationResolution{ZoneAbbreviation: candidates[0], Ambiguous: len(candidates) > 1}
+	if !res.Ambiguous {
+		return res, nil
+	}
+
+	for _, z := range candidates {
+		res.Candidates = append(res.Candidates, z.Name)
+	}
+
+	preferred, ok := r.preferences[abbr]
+	if !ok && r.strict {
+		return nil, fmt.Errorf("%w: %s could be %s", ErrAmbiguousAbbreviation, abbr, strings.Join(res.Candidates, ", "))
+	}
+	for _, z := range candidates {
+		if z.Name == preferred {
+			res.ZoneAbbreviation = z
+		}
+	}
+
+	return res, nil
+}
+
+// fabricatedZone returns the abbreviation of t's zone if time.Parse (or time.ParseInLocation with loc)
+// didn't know it and fabricated a location with a zero offset
+func fabricatedZone(t time.Time, loc *time.Location) (string, bool) {
+	name, offset := t.Zone()
+	if offset != 0 || t.Location() == time.UTC || t.Location() == time.Local || t.Location() == loc {
+		return "", false
+	}
+	if name == "" || name == "UTC" || name == "GMT" {
+		return "", false
+	}
+	for _, r := range name {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return "", false
+		}
+	}
+	return name, true
+}
@@ -0,0 +1,124 @@
+package epoch_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aahainc/epoch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Timezone abbreviations", func() {
+	Context("AbbreviationResolver", func() {
+		var r *epoch.AbbreviationResolver
+		BeforeEach(func() {
+			r = epoch.NewAbbreviationResolver()
+		})
+
+		It("resolves unambiguous abbreviations", func() {
+			res, err := r.Resolve("cest")
+			Expect(err).Should(Succeed())
+			Expect(res.Abbreviation).To(Equal("CEST"))
+			Expect(res.Offset).To(Equal(2 * 3600))
+			Expect(res.Ambiguous).To(BeFalse())
+		})
+
+		It("resolves ambiguous abbreviations by preference", func() {
+			res, err := r.Resolve("IST")
+			Expect(err).Should(Succeed())
+			Expect(res.Name).To(Equal("India Standard Time"))
+			Expect(res.Offset).To(Equal(5*3600 + 30*60))
+			Expect(res.Ambiguous).To(BeTrue())
+			Expect(res.Candidates).To(ContainElement("Israel Standard Time"))
+
+			Expect(r.Prefer("IST", "Israel Standard Time")).Should(Succeed())
+			res, err = r.Resolve("IST")
+			Expect(err).Should(Succeed())
+			Expect(res.Offset).To(Equal(2 * 3600))
+		})
+
+		It("fails on ambiguous abbreviations in strict mode", func() {
+			r.SetStrict(true)
+			_, err := r.Resolve("CST")
+			Expect(errors.Is(err, epoch.ErrAmbiguousAbbreviation)).To(BeTrue())
+
+			Expect(r.Prefer("CST", "China Standard Time")).Should(Succeed())
+			res, err := r.Resolve("CST")
+			Expect(err).Should(Succeed())
+			Expect(res.Offset).To(Equal(8 * 3600))
+		})
+
+		It("fails on unknown abbreviations", func() {
+			_, err := r.Resolve("XYZT")
+			Expect(errors.Is(err, epoch.ErrUnknownAbbreviation)).To(BeTrue())
+			Expect(errors.Is(r.Prefer("IST", "Iceland Standard Time"), epoch.ErrUnknownAbbreviation)).To(BeTrue())
+		})
+
+		It("can be extended", func() {
+			r.Add(epoch.ZoneAbbreviation{Abbreviation: "MSK", Name: "Moscow Summer Time", Offset: 4 * 3600})
+			Expect(r.IsAmbiguous("MSK")).To(BeTrue())
+			Expect(r.Candidates("MSK")).To(HaveLen(2))
+		})
+	})
+
+	Context("BaseParser", func() {
+		BeforeEach(func() {
+			epoch.BaseParserFormat = "2006-01-02 15:04 MST"
+		})
+		AfterEach(func() {
+			epoch.BaseParserFormat = time.RFC3339
+		})
+
+		DescribeTable("maps abbreviations to offsets", func(input string, expectedOffset int, expectedName string) {
+			t, details, err := epoch.NewBaseParser().Parse(input)
+			Expect(err).Should(Succeed())
+			_, offset := t.Zone()
+			Expect(offset).To(Equal(expectedOffset))
+			Expect(t.Hour()).To(Equal(16))
+			Expect(details.Abbreviation).NotTo(BeNil())
+			Expect(details.Abbreviation.Name).To(Equal(expectedName))
+		},
+			Entry("PST", "2020-01-02 16:30 PST", -8*3600, "Pacific Standard Time"),
+			Entry("AEST", "2020-07-02 16:30 AEST", 10*3600, "Australian Eastern Standard Time"),
+			Entry("IST", "2020-07-02 16:30 IST", 5*3600+30*60, "India Standard Time"),
+			Entry("CST", "2020-07-02 16:30 CST", -6*3600, "Central Standard Time"),
+		)
+
+		It("resolves abbreviations when a location is given", func() {
+			t, details, err := epoch.NewBaseParser().Parse("2020-07-02 16:30 CEST", time.UTC)
+			Expect(err).Should(Succeed())
+			Expect(t).To(BeTemporally("==", time.Date(2020, time.July, 2, 14, 30, 0, 0, time.UTC)))
+			Expect(details.Abbreviation.Abbreviation).To(Equal("CEST"))
+		})
+
+		It("uses the configured resolver", func() {
+			r := epoch.NewAbbreviationResolver()
+			Expect(r.Prefer("IST", "Irish Standard Time")).Should(Succeed())
+
+			t, details, err := epoch.NewBaseParser().SetAbbreviationResolver(r).Parse("2020-07-02 16:30 IST")
+			Expect(err).Should(Succeed())
+			Expect(t).To(BeTemporally("==", time.Date(2020, time.July, 2, 15, 30, 0, 0, time.UTC)))
+			Expect(details.Abbreviation.Ambiguous).To(BeTrue())
+		})
+
+		It("fails on ambiguous abbreviations with a strict resolver", func() {
+			r := epoch.NewAbbreviationResolver().SetStrict(true)
+			_, _, err := epoch.NewBaseParser().SetAbbreviationResolver(r).Parse("2020-07-02 16:30 IST")
+			Expect(errors.Is(err, epoch.ErrAmbiguousAbbreviation)).To(BeTrue())
+		})
+
+		It("keeps loading known zone names", func() {
+			loc, _ := time.LoadLocation("MST")
+			t, details, err := epoch.NewBaseParser().Parse("2020-01-02 16:30 MST")
+			Expect(err).Should(Succeed())
+			Expect(t).To(Equal(time.Date(2020, time.January, 2, 16, 30, 0, 0, loc)))
+			Expect(details.Abbreviation).To(BeNil())
+		})
+
+		It("fails on unknown abbreviations", func() {
+			_, _, err := epoch.NewBaseParser().Parse("2020-01-02 16:30 XYZT")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,160 @@
+package epoch
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrAmbiguousTime = fmt.Errorf("ambiguous time")
+)
+
+// errParserMismatch is returned internally when the anchor of an expression doesn't match a parser
+var errParserMismatch = fmt.Errorf("parser doesn't match")
+
+// ParseCandidate is a result of a single parser that matches the input (see TimeParser.ParseAll)
+type ParseCandidate struct {
+	// ParserName is the name of the matching parser
+	ParserName string `json:"parser_name"`
+	// Time is the parsed time (zero if the parser failed)
+	Time time.Time `json:"time"`
+	// Details are details of parsing (nil if the parser failed)
+	Details *ParseDetails `json:"details,omitempty"`
+	// Error is the error message of the parser that matched the input, but failed to parse it
+	Error string `json:"error,omitempty"`
+}
+
+// OK checks if the parser succeeded
+func (c ParseCandidate) OK() bool {
+	return c.Error == ""
+}
+
+// Explanation describes how the input is understood by all the parsers (see TimeParser.Explain)
+type Explanation struct {
+	Input string `json:"input"`
+	// Candidates are results of all matching parsers in order of matching
+	Candidates []ParseCandidate `json:"candidates"`
+	// Chosen is the name of the first parser that succeeded (empty if every matching parser failed)
+	Chosen string `json:"chosen"`
+	// Ambiguous is true if successful candidates give different times
+	Ambiguous bool `json:"ambiguous"`
+}
+
+// ParseAll parses the given string with every active parser that matches it (not only the first one),
+// so inputs like "20240101" (unix seconds or a compact date?) can be detected.
+// Compact dates are opt-in: enable them with GetAllParsers() or NewCompactDateParser(), default parsers give
+// a single candidate for "20240101".
+// Interval arithmetics and zone qualifiers are applied to every candidate the same way as by Parse.
+//
+// In strict mode (see WithStrictAmbiguity) ErrAmbiguousTime is returned along with candidates
+// if successful candidates disagree.
+func (tp *TimeParser) ParseAll(s string, locArg ...*time.Location) ([]ParseCandidate, error) {
+	var candidates []ParseCandidate
+	for _, parser := range tp.activeParsers() {
+		parser := parser
+		parsed := false
+		parseAnchor := func(anchor string, locArg ...*time.Location) (time.Time, *ParseDetails, Parser, error) {
+			t, details, ok, err := tryParse(parser, anchor, locArg...)
+			if !ok {
+				return time.Time{}, nil, nil, errParserMismatch
+			}
+			parsed = true
+			return t, details, parser, err
+		}
+
+		e, t, details, err := tp.compileWith(parseAnchor, s, locArg...)
+		switch {
+		case errors.Is(err, errParserMismatch):
+			continue
+		case err != nil && !parsed:
+			// the expression itself is invalid (e.g. an unknown zone qualifier), it doesn't depend on the parser
+			return nil, fmt.Errorf("failed to parse time: %w", err)
+		case err != nil:
+			candidates = append(candidates, ParseCandidate{ParserName: parser.Name(), Error: err.Error()})
+			continue
+		}
+
+		t, details = e.apply(t, details)
+		candidates = append(candidates, ParseCandidate{ParserName: parser.Name(), Time: t, Details: details})
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("failed to parse time: unsupported time format")
+	}
+
+	if tp.strictAmbiguity && candidatesDisagree(candidates) {
+		return candidates, fmt.Errorf("%w: %s is understood differently by %s", ErrAmbiguousTime, s, candidateNames(candidates))
+	}
+
+	return candidates, nil
+}
+
+// Explain is the same as ParseAll, but also tells which parser Parse chooses and whether the input is ambiguous.
+// It doesn't fail on ambiguous inputs even in strict mode.
+func (tp *TimeParser) Explain(s string, locArg ...*time.Location) (*Explanation, error) {
+	candidates, err := tp.ParseAll(s, locArg...)
+	if err != nil && !errors.Is(err, ErrAmbiguousTime) {
+		return nil, err
+	}
+
+	e := &Explanation{
+		Input:      s,
+		Candidates: candidates,
+		Ambiguous:  candidatesDisagree(candidates),
+	}
+	for _, c := range candidates {
+		if c.OK() {
+			e.Chosen = c.ParserName
+			break
+		}
+	}
+	return e, nil
+}
+
+// checkAmbiguity checks that all the other matching parsers give the same time as the chosen one
+func (tp *TimeParser) checkAmbiguity(s string, t time.Time, chosen Parser, locArg ...*time.Location) error {
+	names := []string{chosen.Name()}
+	for _, parser := range tp.activeParsers() {
+		if parser == chosen {
+			continue
+		}
+
+		other, _, ok, err := tryParse(parser, s, locArg...)
+		if ok && err == nil && !other.Equal(t) {
+			names = append(names, parser.Name())
+		}
+	}
+
+	if len(names) > 1 {
+		return fmt.Errorf("%w: %s is understood differently by %s", ErrAmbiguousTime, s, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+func candidatesDisagree(candidates []ParseCandidate) bool {
+	var first *ParseCandidate
+	for i := range candidates {
+		c := &candidates[i]
+		if !c.OK() {
+			continue
+		}
+		if first == nil {
+			first = c
+		} else if !c.Time.Equal(first.Time) {
+			return true
+		}
+	}
+	return false
+}
+
+func candidateNames(candidates []ParseCandidate) string {
+	names := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		if c.OK() {
+			names = append(names, c.ParserName)
+		}
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,160 @@
+package epoch_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/aahainc/epoch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Ambiguity detection", func() {
+	compactDate := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	unixSeconds := time.Unix(20240101, 0).UTC()
+
+	BeforeEach(func() {
+		epoch.BaseParserFormat = time.RFC3339
+	})
+
+	It("parses compact dates", func() {
+		p := epoch.NewCompactDateParser()
+		Expect(p.Match("20240101")).To(BeTrue())
+		Expect(p.Match("20241301")).To(BeFalse())
+		Expect(p.Match("2024011")).To(BeFalse())
+
+		t, details, err := p.Parse("20240101")
+		Expect(err).To(Succeed())
+		Expect(t).To(Equal(compactDate))
+		Expect(details.Format).To(Equal("20060102"))
+	})
+
+	It("returns every matching parser", func() {
+		p := epoch.NewTimeParser(epoch.WithParsers(epoch.GetAllParsers()...))
+
+		candidates, err := p.ParseAll("20240101", time.UTC)
+		Expect(err).To(Succeed())
+		Expect(candidates).To(HaveLen(2))
+		Expect(candidates[0].ParserName).To(Equal("unix-seconds"))
+		Expect(candidates[0].Time).To(Equal(unixSeconds))
+		Expect(candidates[1].ParserName).To(Equal("compact-date"))
+		Expect(candidates[1].Time).To(Equal(compactDate))
+
+		// Parse still picks the first one
+		t, err := p.Parse("20240101", time.UTC)
+		Expect(err).To(Succeed())
+		Expect(t).To(Equal(unixSeconds))
+	})
+
+	It("doesn't detect compact dates with default parsers", func() {
+		p := epoch.NewTimeParser(epoch.WithStrictAmbiguity())
+
+		candidates, err := p.ParseAll("20240101", time.UTC)
+		Expect(err).To(Succeed())
+		Expect(candidates).To(HaveLen(1))
+		Expect(candidates[0].ParserName).To(Equal("unix-seconds"))
+
+		_, err = p.Parse("20240101", time.UTC)
+		Expect(err).To(Succeed())
+
+		e, err := p.Explain("20240101", time.UTC)
+		Expect(err).To(Succeed())
+		Expect(e.Ambiguous).To(BeFalse())
+
+		// enabling the compact date parser makes the strict mode report it
+		Expect(p.Register(epoch.NewCompactDateParser())).To(Succeed())
+		_, err = p.Parse("20240101", time.UTC)
+		Expect(err).To(MatchError(epoch.ErrAmbiguousTime))
+	})
+
+	It("applies interval arithmetics and zone qualifiers to every candidate", func() {
+		p := epoch.NewTimeParser(epoch.WithParsers(epoch.GetAllParsers()...), epoch.WithIntervalArithmetics())
+
+		candidates, err := p.ParseAll("20240101,1d@UTC")
+		Expect(err).To(Succeed())
+		Expect(candidates).To(HaveLen(2))
+		Expect(candidates[0].Time).To(Equal(unixSeconds.AddDate(0, 0, 1)))
+		Expect(candidates[1].Time).To(Equal(compactDate.AddDate(0, 0, 1)))
+		Expect(candidates[1].Details.Arithmetics.RawIntervals).To(Equal([]string{"1d"}))
+
+		_, err = p.ParseAll("20240101@Mars/Olympus")
+		Expect(err).To(MatchError(epoch.ErrInvalidLocation))
+
+		_, err = p.ParseAll("not a time")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("explains the input", func() {
+		p := epoch.NewTimeParser(epoch.WithParsers(epoch.GetAllParsers()...))
+
+		e, err := p.Explain("20240101", time.UTC)
+		Expect(err).To(Succeed())
+		Expect(e.Chosen).To(Equal("unix-seconds"))
+		Expect(e.Ambiguous).To(BeTrue())
+
+		e, err = p.Explain("2024-01-01T00:00:00Z")
+		Expect(err).To(Succeed())
+		Expect(e.Chosen).To(Equal("base"))
+		Expect(e.Candidates).To(HaveLen(1))
+		Expect(e.Ambiguous).To(BeFalse())
+
+		data, err := json.Marshal(e)
+		Expect(err).To(Succeed())
+		Expect(string(data)).To(ContainSubstring(`"parser_name":"base"`))
+	})
+
+	It("chooses the first successful candidate", func() {
+		p := epoch.NewTimeParser(epoch.WithParsers(failingParser{}, epoch.NewBaseParser()))
+
+		e, err := p.Explain("2024-01-01T00:00:00Z")
+		Expect(err).To(Succeed())
+		Expect(e.Candidates).To(HaveLen(2))
+		Expect(e.Candidates[0].OK()).To(BeFalse())
+		Expect(e.Chosen).To(Equal("base"))
+
+		p = epoch.NewTimeParser(epoch.WithParsers(failingParser{}))
+		e, err = p.Explain("2024-01-01T00:00:00Z")
+		Expect(err).To(Succeed())
+		Expect(e.Chosen).To(BeEmpty())
+	})
+
+	Context("Strict mode", func() {
+		var p *epoch.TimeParser
+		BeforeEach(func() {
+			p = epoch.NewTimeParser(epoch.WithParsers(epoch.GetAllParsers()...), epoch.WithStrictAmbiguity())
+		})
+
+		It("fails when candidates disagree", func() {
+			_, err := p.Parse("20240101")
+			Expect(err).To(MatchError(epoch.ErrAmbiguousTime))
+			Expect(err.Error()).To(ContainSubstring("unix-seconds, compact-date"))
+
+			candidates, err := p.ParseAll("20240101")
+			Expect(err).To(MatchError(epoch.ErrAmbiguousTime))
+			Expect(candidates).To(HaveLen(2))
+
+			e, err := p.Explain("20240101")
+			Expect(err).To(Succeed())
+			Expect(e.Ambiguous).To(BeTrue())
+		})
+
+		It("accepts unambiguous inputs", func() {
+			t, err := p.Parse("1577829600")
+			Expect(err).To(Succeed())
+			Expect(t.Unix()).To(Equal(int64(1577829600)))
+
+			_, err = p.Parse("2024-01-01T00:00:00Z")
+			Expect(err).To(Succeed())
+		})
+	})
+})
+
+// failingParser matches any input, but fails to parse it
+type failingParser struct{}
+
+func (failingParser) Match(string) bool { return true }
+func (failingParser) Name() string      { return "failing" }
+func (failingParser) Parse(string, ...*time.Location) (time.Time, *epoch.ParseDetails, error) {
+	return time.Time{}, nil, errors.New("broken")
+}
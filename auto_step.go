@@ -0,0 +1,92 @@
+package epoch
+
+import (
+	"fmt"
+	"sort"
+)
+
+var (
+	ErrTooManyPoints = fmt.Errorf("too many points")
+)
+
+// defaultSteps are "nice" steps AutoStep chooses from
+var defaultSteps = []string{
+	"1s", "5s", "10s", "15s", "30s",
+	"1m", "5m", "10m", "15m", "30m",
+	"1h", "2h", "3h", "6h", "12h",
+	"1d", "1w", "1mo", "1q", "1y",
+}
+
+// DefaultSteps returns steps AutoStep chooses from when no steps are given: 1s, 5s, ..., 1m, 5m, ..., 1h, ..., 1d, 1w, 1mo, 1q, 1y
+func DefaultSteps() []*Interval {
+	steps := make([]*Interval, len(defaultSteps))
+	for i, s := range defaultSteps {
+		steps[i] = MustParseInterval(s)
+	}
+	return steps
+}
+
+// AutoStep chooses the smallest of the allowed steps (DefaultSteps if none are given)
+// that splits the range into at most maxPoints aligned buckets (see Buckets),
+// like $__interval of Grafana. ErrTooManyPoints is returned if none of the steps fits.
+func AutoStep(r Range, maxPoints int, allowed []*Interval, options ...ScheduleOption) (*Interval, error) {
+	if len(allowed) == 0 {
+		allowed = DefaultSteps()
+	}
+
+	cfg := newScheduleConfig(options)
+	steps := append([]*Interval(nil), allowed...)
+	sort.SliceStable(steps, func(i, j int) bool {
+		return cfg.units.longer(steps[j], steps[i])
+	})
+
+	for _, step := range steps {
+		s, err := NewIntervalSchedule(step, options...)
+		if err != nil {
+			return nil, err
+		}
+		if countPoints(s, r, maxPoints) <= maxPoints {
+			return s.Interval(), nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: even %s gives more than %d points", ErrTooManyPoints, steps[len(steps)-1], maxPoints)
+}
+
+// countPoints returns the number of buckets of the schedule covering the range, up to limit+1
+func countPoints(s *IntervalSchedule, r Range, limit int) int {
+	if r.IsEmpty() {
+		return 0
+	}
+
+	// steps vary (months, DST) but not twice, so steps far too short are rejected without iterating them
+	first := s.Truncate(r.Start)
+	step := s.Next(first).Sub(first)
+	if step <= 0 || int64(r.End.Sub(first)/step) > 2*int64(limit)+2 {
+		return limit + 1
+	}
+
+	n := 0
+	for b := first; b.Before(r.End) && n <= limit; b = s.Next(b) {
+		if b.IsZero() {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// AlignRange extends the range to boundaries of the step: Start is truncated, End is rounded up
+// (see TruncateToInterval). Schedule options set the location, offset and units of the alignment.
+func AlignRange(r Range, step *Interval, options ...ScheduleOption) (Range, error) {
+	s, err := NewIntervalSchedule(step, options...)
+	if err != nil {
+		return Range{}, err
+	}
+
+	end := s.Truncate(r.End)
+	if end.Before(r.End) {
+		end = s.Next(end)
+	}
+	return Range{Start: s.Truncate(r.Start), End: end}, nil
+}
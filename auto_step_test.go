@@ -0,0 +1,76 @@
+package epoch_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aahainc/epoch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AutoStep", func() {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	last := func(d time.Duration) epoch.Range {
+		return epoch.NewRange(start, start.Add(d))
+	}
+
+	DescribeTable("chooses the smallest nice step", func(r epoch.Range, maxPoints int, expected string) {
+		step, err := epoch.AutoStep(r, maxPoints, nil)
+		Expect(err).To(Succeed())
+		Expect(step.String()).To(Equal(expected))
+	},
+		Entry("1 hour in 100 points", last(time.Hour), 100, "1m"),
+		Entry("1 hour in 60 points", last(time.Hour), 60, "1m"),
+		Entry("1 hour in 59 points", last(time.Hour), 59, "5m"),
+		Entry("1 day in 1000 points", last(24*time.Hour), 1000, "5m"),
+		Entry("30 days in 100 points", last(30*24*time.Hour), 100, "12h"),
+		Entry("1 year in 12 points", epoch.NewRange(start, start.AddDate(1, 0, 0)), 12, "1mo"),
+		Entry("1 year in 11 points", epoch.NewRange(start, start.AddDate(1, 0, 0)), 11, "1q"),
+		Entry("empty range", last(0), 1, "1s"),
+	)
+
+	It("counts unaligned buckets", func() {
+		// 00:30-01:30 touches two hours
+		r := epoch.NewRange(start.Add(30*time.Minute), start.Add(90*time.Minute))
+		step, err := epoch.AutoStep(r, 1, []*epoch.Interval{epoch.MustParseInterval("1h"), epoch.MustParseInterval("1d")})
+		Expect(err).To(Succeed())
+		Expect(step.String()).To(Equal("1d"))
+	})
+
+	It("uses allowed steps in any order", func() {
+		allowed := []*epoch.Interval{epoch.MustParseInterval("1d"), epoch.MustParseInterval("15m"), epoch.MustParseInterval("1h")}
+		step, err := epoch.AutoStep(last(12*time.Hour), 20, allowed)
+		Expect(err).To(Succeed())
+		Expect(step.String()).To(Equal("1h"))
+	})
+
+	It("fails if none of steps fits", func() {
+		_, err := epoch.AutoStep(last(24*time.Hour), 10, []*epoch.Interval{epoch.MustParseInterval("1h")})
+		Expect(errors.Is(err, epoch.ErrTooManyPoints)).To(BeTrue())
+	})
+
+	Context("AlignRange", func() {
+		It("extends the range to boundaries", func() {
+			r := epoch.NewRange(start.Add(7*time.Minute), start.Add(52*time.Minute))
+			aligned, err := epoch.AlignRange(r, epoch.MustParseInterval("15m"))
+			Expect(err).To(Succeed())
+			Expect(aligned).To(Equal(epoch.NewRange(start, start.Add(time.Hour))))
+		})
+
+		It("keeps aligned ends", func() {
+			r := epoch.NewRange(start, start.Add(time.Hour))
+			aligned, err := epoch.AlignRange(r, epoch.MustParseInterval("15m"))
+			Expect(err).To(Succeed())
+			Expect(aligned).To(Equal(r))
+		})
+
+		It("aligns calendar units in a location", func() {
+			berlin, _ := time.LoadLocation("Europe/Berlin")
+			r := epoch.NewRange(start.AddDate(0, 0, 10), start.AddDate(0, 1, 10))
+			aligned, err := epoch.AlignRange(r, epoch.MustParseInterval("1mo"), epoch.WithScheduleLocation(berlin))
+			Expect(err).To(Succeed())
+			Expect(aligned.String()).To(Equal("2024-01-01T00:00:00+01:00/2024-03-01T00:00:00+01:00"))
+		})
+	})
+})
@@ -0,0 +1,224 @@
+package epoch
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultBatchSampleSize is the number of rows ParseBatch detects the format of one by one
+const defaultBatchSampleSize = 100
+
+// BatchOption configures TimeParser.ParseBatch
+type BatchOption func(*batchConfig)
+
+type batchConfig struct {
+	sampleSize int
+	workers    int
+	location   *time.Location
+}
+
+// WithBatchSampleSize sets the number of rows parsed with full detection before locking onto the dominant parser
+// (100 by default). Zero or negative size disables locking.
+func WithBatchSampleSize(n int) BatchOption {
+	return func(c *batchConfig) {
+		c.sampleSize = n
+	}
+}
+
+// WithBatchWorkers parses rows after the sample in the given number of goroutines (1 by default)
+func WithBatchWorkers(n int) BatchOption {
+	return func(c *batchConfig) {
+		c.workers = n
+	}
+}
+
+// WithBatchLocation sets the location rows are parsed in (same as locArg of Parse)
+func WithBatchLocation(loc *time.Location) BatchOption {
+	return func(c *batchConfig) {
+		c.location = loc
+	}
+}
+
+// BatchResult is the result of TimeParser.ParseBatch
+type BatchResult struct {
+	// Times are parsed times in order of inputs (zero for rows that failed)
+	Times []time.Time
+	// Errors are errors of rows in order of inputs (nil for rows that succeeded)
+	Errors []error
+	// Summary tells which parser and format won
+	Summary BatchSummary
+}
+
+// BatchSummary describes how a batch was parsed
+type BatchSummary struct {
+	Total  int `json:"total"`
+	Parsed int `json:"parsed"`
+	Failed int `json:"failed"`
+	// Parsers is the number of rows parsed by each parser
+	Parsers map[string]int `json:"parsers"`
+	// Parser is the name of the parser most rows were parsed with
+	Parser string `json:"parser"`
+	// Format is the format of the dominant parser (empty for parsers without a format, e.g. unix timestamps)
+	Format string `json:"format,omitempty"`
+	// Locked is true if rows after the sample were parsed by the dominant parser without detection
+	Locked bool `json:"locked"`
+}
+
+// ParseBatch parses a column of times that most likely share the same format (e.g. a CSV column).
+//
+// The first rows (see WithBatchSampleSize) are parsed as by ParseExt, then the parser locks onto
+// the dominant parser of the sample and tries it first for the rest of rows, falling back to
+// the full detection for rows it doesn't match. Locking is disabled in strict mode (see WithStrictAmbiguity),
+// so every row is checked for ambiguity.
+func (tp *TimeParser) ParseBatch(inputs []string, options ...BatchOption) *BatchResult {
+	cfg := batchConfig{sampleSize: defaultBatchSampleSize, workers: 1}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	var locArg []*time.Location
+	if cfg.location != nil {
+		locArg = append(locArg, cfg.location)
+	}
+
+	res := &BatchResult{
+		Times:  make([]time.Time, len(inputs)),
+		Errors: make([]error, len(inputs)),
+	}
+	stats := newBatchStats()
+
+	sample := cfg.sampleSize
+	if sample <= 0 || tp.strictAmbiguity || sample > len(inputs) {
+		sample = len(inputs)
+	}
+	tp.parseRows(inputs, 0, sample, tp.parseTime, locArg, res, stats)
+
+	if sample < len(inputs) {
+		parseAnchor := anchorParser(tp.parseTime)
+		if dominant := stats.dominant(); dominant != nil {
+			res.Summary.Locked = true
+			parseAnchor = tp.lockedParser(dominant)
+		}
+		tp.parseRowsConcurrently(inputs, sample, cfg.workers, parseAnchor, locArg, res, stats)
+	}
+
+	res.Summary.Total = len(inputs)
+	res.Summary.Parsers = stats.counts
+	for _, n := range stats.counts {
+		res.Summary.Parsed += n
+	}
+	res.Summary.Failed = res.Summary.Total - res.Summary.Parsed
+	if dominant := stats.dominant(); dominant != nil {
+		res.Summary.Parser = dominant.Name()
+		res.Summary.Format = stats.formats[dominant.Name()]
+	}
+
+	return res
+}
+
+// lockedParser tries the given parser first and falls back to the full detection
+func (tp *TimeParser) lockedParser(parser Parser) anchorParser {
+	return func(anchor string, locArg ...*time.Location) (time.Time, *ParseDetails, Parser, error) {
+		t, details, ok, err := tryParse(parser, anchor, locArg...)
+		if !ok {
+			return tp.parseTime(anchor, locArg...)
+		}
+		if err != nil {
+			return time.Time{}, nil, nil, err
+		}
+		return t, details, parser, nil
+	}
+}
+
+// parseRowsConcurrently parses rows from the given one till the end in the given number of goroutines
+func (tp *TimeParser) parseRowsConcurrently(inputs []string, from, workers int, parseAnchor anchorParser, locArg []*time.Location, res *BatchResult, stats *batchStats) {
+	rows := len(inputs) - from
+	if workers > rows {
+		workers = rows
+	}
+	if workers <= 1 {
+		tp.parseRows(inputs, from, len(inputs), parseAnchor, locArg, res, stats)
+		return
+	}
+
+	chunk := (rows + workers - 1) / workers
+	workerStats := make([]*batchStats, 0, workers)
+	var wg sync.WaitGroup
+	for start := from; start < len(inputs); start += chunk {
+		end := start + chunk
+		if end > len(inputs) {
+			end = len(inputs)
+		}
+
+		s := newBatchStats()
+		workerStats = append(workerStats, s)
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			tp.parseRows(inputs, start, end, parseAnchor, locArg, res, s)
+		}(start, end)
+	}
+	wg.Wait()
+
+	for _, s := range workerStats {
+		stats.merge(s)
+	}
+}
+
+// parseRows parses rows [from, to) writing into the result (rows don't overlap between goroutines)
+func (tp *TimeParser) parseRows(inputs []string, from, to int, parseAnchor anchorParser, locArg []*time.Location, res *BatchResult, stats *batchStats) {
+	for i := from; i < to; i++ {
+		e, t, details, err := tp.compileWith(parseAnchor, inputs[i], locArg...)
+		if err != nil {
+			res.Errors[i] = fmt.Errorf("failed to parse time: %w", err)
+			continue
+		}
+
+		res.Times[i], _ = e.apply(t, details)
+		stats.add(e.parser, details.Format)
+	}
+}
+
+// batchStats counts rows per parser
+type batchStats struct {
+	counts  map[string]int
+	formats map[string]string
+	// parsers are parsers in order of appearance, so ties are resolved in favour of the first one
+	parsers []Parser
+}
+
+func newBatchStats() *batchStats {
+	return &batchStats{counts: make(map[string]int), formats: make(map[string]string)}
+}
+
+func (s *batchStats) add(parser Parser, format string) {
+	name := parser.Name()
+	if _, ok := s.counts[name]; !ok {
+		s.parsers = append(s.parsers, parser)
+		s.formats[name] = format
+	}
+	s.counts[name]++
+}
+
+func (s *batchStats) merge(other *batchStats) {
+	for _, parser := range other.parsers {
+		name := parser.Name()
+		if _, ok := s.counts[name]; !ok {
+			s.parsers = append(s.parsers, parser)
+			s.formats[name] = other.formats[name]
+		}
+		s.counts[name] += other.counts[name]
+	}
+}
+
+// dominant returns the parser most rows were parsed with (nil if no rows were parsed)
+func (s *batchStats) dominant() Parser {
+	var best Parser
+	for _, parser := range s.parsers {
+		if best == nil || s.counts[parser.Name()] > s.counts[best.Name()] {
+			best = parser
+		}
+	}
+	return best
+}
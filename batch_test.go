@@ -0,0 +1,94 @@
+package epoch_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aahainc/epoch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseBatch", func() {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	var p *epoch.TimeParser
+	var column []string
+	BeforeEach(func() {
+		epoch.BaseParserFormat = time.RFC3339
+		p = epoch.NewTimeParser()
+
+		column = nil
+		for i := 0; i < 1000; i++ {
+			column = append(column, start.Add(time.Duration(i)*time.Minute).Format(time.RFC3339))
+		}
+	})
+
+	It("parses a column of times", func() {
+		column[500] = "not a time"
+		column[700] = fmt.Sprint(start.Unix())
+
+		res := p.ParseBatch(column, epoch.WithBatchSampleSize(10))
+		Expect(res.Times).To(HaveLen(1000))
+		Expect(res.Errors).To(HaveLen(1000))
+
+		Expect(res.Times[0]).To(Equal(start))
+		Expect(res.Times[999]).To(Equal(start.Add(999 * time.Minute)))
+		Expect(res.Errors[500]).To(HaveOccurred())
+		Expect(res.Times[500].IsZero()).To(BeTrue())
+		// rows in other formats fall back to the detection
+		Expect(res.Errors[700]).To(Succeed())
+		Expect(res.Times[700].Unix()).To(Equal(start.Unix()))
+
+		Expect(res.Summary).To(Equal(epoch.BatchSummary{
+			Total:   1000,
+			Parsed:  999,
+			Failed:  1,
+			Parsers: map[string]int{"base": 998, "unix-seconds": 1},
+			Parser:  "base",
+			Format:  time.RFC3339,
+			Locked:  true,
+		}))
+	})
+
+	It("reports errors of the locked parser the same way as of the detection", func() {
+		epoch.BaseParserFormat = "2006-01-02 15:04"
+		defer func() { epoch.BaseParserFormat = time.RFC3339 }()
+		la, _ := time.LoadLocation("America/Los_Angeles")
+		p = epoch.NewTimeParser(epoch.WithParsers(epoch.NewBaseParser().SetDSTPolicy(epoch.DSTPolicyError)))
+
+		column = []string{"2019-03-10 01:30", "2019-03-10 02:30", "2019-03-10 03:30", "2019-03-10 02:30"}
+		res := p.ParseBatch(column, epoch.WithBatchSampleSize(2), epoch.WithBatchLocation(la))
+		Expect(res.Summary.Locked).To(BeTrue())
+		Expect(res.Errors[3]).To(MatchError("failed to parse time: nonexistent local time: 2019-03-10 02:30:00 in America/Los_Angeles"))
+		Expect(res.Errors[3].Error()).To(Equal(res.Errors[1].Error()))
+	})
+
+	It("parses rows in parallel", func() {
+		sequential := p.ParseBatch(column, epoch.WithBatchSampleSize(10))
+		parallel := p.ParseBatch(column, epoch.WithBatchSampleSize(10), epoch.WithBatchWorkers(8))
+		Expect(parallel).To(Equal(sequential))
+	})
+
+	It("parses rows in the given location", func() {
+		tokyo, _ := time.LoadLocation("Asia/Tokyo")
+		res := p.ParseBatch([]string{"1704067200", "1704067260"}, epoch.WithBatchLocation(tokyo))
+		Expect(res.Times[0].Location()).To(Equal(tokyo))
+		Expect(res.Summary.Locked).To(BeFalse())
+		Expect(res.Summary.Parser).To(Equal("unix-seconds"))
+	})
+
+	It("doesn't lock in strict mode", func() {
+		p = epoch.NewTimeParser(epoch.WithParsers(epoch.GetAllParsers()...), epoch.WithStrictAmbiguity())
+		res := p.ParseBatch(append(column, "20240101"), epoch.WithBatchSampleSize(10))
+		Expect(res.Summary.Locked).To(BeFalse())
+		Expect(res.Errors[1000]).To(MatchError(epoch.ErrAmbiguousTime))
+	})
+
+	It("handles empty batches", func() {
+		res := p.ParseBatch(nil)
+		Expect(res.Times).To(BeEmpty())
+		Expect(res.Summary.Total).To(BeZero())
+		Expect(res.Summary.Parser).To(BeEmpty())
+	})
+})
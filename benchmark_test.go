@@ -0,0 +1,134 @@
+package epoch_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aahainc/epoch"
+)
+
+var builtinParserInputs = []struct {
+	parser epoch.Parser
+	input  string
+}{
+	{epoch.NewBaseParser(), "2018-03-01T12:30:00Z"},
+	{epoch.NewUnixSecondsParser(), "1577829600"},
+	{epoch.NewUnixMilliParser(), "1577829600123"},
+	{epoch.NewCompactDateParser(), "20240101"},
+	{epoch.NewAliasesParser(), "yesterday"},
+}
+
+// BenchmarkTimeParserParse measures TimeParser.Parse with each built-in parser (single pass via TryParser)
+func BenchmarkTimeParserParse(b *testing.B) {
+	for _, bi := range builtinParserInputs {
+		p := epoch.NewTimeParser(epoch.WithParsers(bi.parser))
+		input := bi.input
+		b.Run(bi.parser.Name(), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := p.Parse(input, time.UTC); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkMatchParse measures the two-pass path (Match and then Parse) used for parsers without TryParse
+func BenchmarkMatchParse(b *testing.B) {
+	for _, bi := range builtinParserInputs {
+		parser, input := bi.parser, bi.input
+		b.Run(parser.Name(), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if !parser.Match(input) {
+					b.Fatal("no match")
+				}
+				if _, _, err := parser.Parse(input); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkTryParse measures the single-pass path of TryParser
+func BenchmarkTryParse(b *testing.B) {
+	for _, bi := range builtinParserInputs {
+		parser, input := bi.parser.(epoch.TryParser), bi.input
+		b.Run(parser.Name(), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, _, ok, err := parser.TryParse(input); !ok || err != nil {
+					b.Fatal("failed to parse")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParseInterval(b *testing.B) {
+	for _, input := range []string{"5m", "-1.5h", "10mo", "500us"} {
+		input := input
+		b.Run(input, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := epoch.ParseInterval(input); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// TestParseIntervalAllocs checks that ParseInterval doesn't allocate on success
+func TestParseIntervalAllocs(t *testing.T) {
+	var sum float64
+	allocs := testing.AllocsPerRun(100, func() {
+		interval, err := epoch.ParseInterval("-1.5mo")
+		if err == nil {
+			sum += interval.Value
+		}
+	})
+	if allocs != 0 || sum == 0 {
+		t.Fatalf("ParseInterval allocates %v times per run", allocs)
+	}
+}
+
+func BenchmarkTimeAddInterval(b *testing.B) {
+	t := time.Date(2024, time.January, 31, 12, 0, 0, 0, time.UTC)
+	for _, interval := range []*epoch.Interval{
+		epoch.MustParseInterval("5m"),
+		epoch.MustParseInterval("1d"),
+		epoch.MustParseInterval("1mo"),
+		epoch.MustParseInterval("2bd"),
+	} {
+		interval := interval
+		b.Run(interval.String(), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				epoch.TimeAddInterval(t, interval)
+			}
+		})
+	}
+}
+
+func BenchmarkParseBatch(b *testing.B) {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	column := make([]string, 10000)
+	for i := range column {
+		column[i] = start.Add(time.Duration(i) * time.Second).Format(time.RFC3339)
+	}
+
+	p := epoch.NewTimeParser(epoch.WithParsers(epoch.GetAllParsers()...))
+	for _, workers := range []int{1, 4} {
+		workers := workers
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				p.ParseBatch(column, epoch.WithBatchWorkers(workers))
+			}
+		})
+	}
+}
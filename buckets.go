@@ -0,0 +1,62 @@
+package epoch
+
+import (
+	"sort"
+	"time"
+)
+
+// Bucket is an aligned period of a time series with the number of points in it
+type Bucket struct {
+	Range
+	Count int `json:"count"`
+}
+
+// Buckets splits the range into buckets of the step aligned the same way as TruncateToInterval
+// (e.g. "5m" buckets start at :00, :05, ..., "1mo" buckets start on the 1st) and counts timestamps in each of them.
+// The first and the last buckets are whole even if the range isn't aligned.
+// Schedule options set the location, offset and units of the alignment, see IntervalSchedule.
+func Buckets(timestamps []time.Time, r Range, step *Interval, options ...ScheduleOption) ([]Bucket, error) {
+	s, err := NewIntervalSchedule(step, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	periods := s.Split(r)
+	buckets := make([]Bucket, len(periods))
+	for i, p := range periods {
+		buckets[i].Range = p
+	}
+	if len(buckets) == 0 {
+		return buckets, nil
+	}
+
+	covered := Range{Start: buckets[0].Start, End: buckets[len(buckets)-1].End}
+	for _, t := range timestamps {
+		if !covered.Contains(t) {
+			continue
+		}
+		// the first bucket ending after t contains it
+		i := sort.Search(len(buckets), func(i int) bool {
+			return buckets[i].End.After(t)
+		})
+		buckets[i].Count++
+	}
+
+	return buckets, nil
+}
+
+// MissingBuckets returns buckets of the range without any timestamps, see Buckets
+func MissingBuckets(timestamps []time.Time, r Range, step *Interval, options ...ScheduleOption) ([]Range, error) {
+	buckets, err := Buckets(timestamps, r, step, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := []Range{}
+	for _, b := range buckets {
+		if b.Count == 0 {
+			missing = append(missing, b.Range)
+		}
+	}
+	return missing, nil
+}
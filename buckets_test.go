@@ -0,0 +1,66 @@
+package epoch_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aahainc/epoch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Buckets", func() {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	at := func(h, m int) time.Time {
+		return time.Date(2024, time.January, 1, h, m, 0, 0, time.UTC)
+	}
+
+	It("counts timestamps per aligned bucket", func() {
+		timestamps := []time.Time{at(10, 1), at(10, 4), at(10, 12), at(9, 0), at(11, 0)}
+		buckets, err := epoch.Buckets(timestamps, epoch.NewRange(at(10, 2), at(10, 20)), epoch.MustParseInterval("5m"))
+		Expect(err).To(Succeed())
+		Expect(buckets).To(HaveLen(4))
+		// the first bucket is whole, so the point at 10:01 is counted
+		Expect(buckets[0].Range).To(Equal(epoch.NewRange(at(10, 0), at(10, 5))))
+		Expect(buckets[0].Count).To(Equal(2))
+		Expect(buckets[1].Count).To(Equal(0))
+		Expect(buckets[2].Count).To(Equal(1))
+		Expect(buckets[3].Range).To(Equal(epoch.NewRange(at(10, 15), at(10, 20))))
+	})
+
+	It("returns missing buckets", func() {
+		timestamps := []time.Time{at(10, 1), at(10, 12)}
+		missing, err := epoch.MissingBuckets(timestamps, epoch.NewRange(at(10, 0), at(10, 20)), epoch.MustParseInterval("5m"))
+		Expect(err).To(Succeed())
+		Expect(missing).To(Equal([]epoch.Range{epoch.NewRange(at(10, 5), at(10, 10)), epoch.NewRange(at(10, 15), at(10, 20))}))
+	})
+
+	It("aligns calendar units in a location", func() {
+		timestamps := []time.Time{time.Date(2024, time.February, 10, 0, 0, 0, 0, berlin)}
+		r := epoch.NewRange(time.Date(2024, time.January, 15, 0, 0, 0, 0, berlin), time.Date(2024, time.April, 1, 0, 0, 0, 0, berlin))
+		missing, err := epoch.MissingBuckets(timestamps, r, epoch.MustParseInterval("1mo"), epoch.WithScheduleLocation(berlin))
+		Expect(err).To(Succeed())
+		Expect(missing).To(HaveLen(2))
+		Expect(missing[0].String()).To(Equal("2024-01-01T00:00:00+01:00/2024-02-01T00:00:00+01:00"))
+		Expect(missing[1].String()).To(Equal("2024-03-01T00:00:00+01:00/2024-04-01T00:00:00+02:00"))
+	})
+
+	It("follows DST", func() {
+		r := epoch.NewRange(time.Date(2024, time.March, 30, 0, 0, 0, 0, berlin), time.Date(2024, time.April, 1, 0, 0, 0, 0, berlin))
+		buckets, err := epoch.Buckets(nil, r, epoch.MustParseInterval("1d"), epoch.WithScheduleLocation(berlin))
+		Expect(err).To(Succeed())
+		Expect(buckets).To(HaveLen(2))
+		Expect(buckets[1].Duration()).To(Equal(23 * time.Hour))
+	})
+
+	It("returns no buckets for an empty range", func() {
+		buckets, err := epoch.Buckets(nil, epoch.NewRange(at(10, 0), at(10, 0)), epoch.MustParseInterval("5m"))
+		Expect(err).To(Succeed())
+		Expect(buckets).To(BeEmpty())
+	})
+
+	It("fails on steps that can't be aligned", func() {
+		_, err := epoch.Buckets(nil, epoch.NewRange(at(10, 0), at(11, 0)), epoch.MustParseInterval("1.5mo"))
+		Expect(errors.Is(err, epoch.ErrInvalidSchedule)).To(BeTrue())
+	})
+})
@@ -0,0 +1,229 @@
+package epoch
+
+import (
+	"os"
+	"sort"
+	"time"
+)
+
+// HolidayCalendar decides which days are business days
+type HolidayCalendar interface {
+	// IsBusinessDay checks if the day of t (in t's location) is a business day
+	IsBusinessDay(t time.Time) bool
+}
+
+// Holiday is a single non-business day
+type Holiday struct {
+	Date time.Time `json:"date"`
+	Name string    `json:"name"`
+}
+
+// civilDate is a calendar day without time and location
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func civilDateOf(t time.Time) civilDate {
+	y, m, d := t.Date()
+	return civilDate{y, m, d}
+}
+
+// maxNonBusinessDays limits the search for a business day,
+// so a calendar without business days does not hang the caller (see AddBusinessDays)
+const maxNonBusinessDays = 10 * 366
+
+var _ HolidayCalendar = &StaticHolidayCalendar{}
+
+// StaticHolidayCalendar is a HolidayCalendar with configurable weekend days and a static list of holidays.
+// It's not safe to modify it concurrently with reading.
+type StaticHolidayCalendar struct {
+	weekend  [7]bool
+	holidays map[civilDate]string
+}
+
+// NewStaticHolidayCalendar returns a calendar with Saturday and Sunday as weekend days and no holidays
+func NewStaticHolidayCalendar() *StaticHolidayCalendar {
+	c := &StaticHolidayCalendar{holidays: make(map[civilDate]string)}
+	return c.SetWeekend(time.Saturday, time.Sunday)
+}
+
+// DefaultHolidayCalendar is the calendar used by the built-in business-day unit and aliases.
+// It knows only weekends (Saturday and Sunday).
+var DefaultHolidayCalendar HolidayCalendar = NewStaticHolidayCalendar()
+
+// SetWeekend replaces weekend days of the calendar
+func (c *StaticHolidayCalendar) SetWeekend(days ...time.Weekday) *StaticHolidayCalendar {
+	c.weekend = [7]bool{}
+	for _, d := range days {
+		c.weekend[d] = true
+	}
+	return c
+}
+
+// AddHoliday marks the day of t as a holiday
+func (c *StaticHolidayCalendar) AddHoliday(t time.Time, name string) *StaticHolidayCalendar {
+	c.holidays[civilDateOf(t)] = name
+	return c
+}
+
+// AddHolidays marks all given holidays as non-business days
+func (c *StaticHolidayCalendar) AddHolidays(holidays ...Holiday) *StaticHolidayCalendar {
+	for _, h := range holidays {
+		c.AddHoliday(h.Date, h.Name)
+	}
+	return c
+}
+
+// Holidays returns all holidays of the calendar sorted by date
+func (c *StaticHolidayCalendar) Holidays() []Holiday {
+	holidays := make([]Holiday, 0, len(c.holidays))
+	for d, name := range c.holidays {
+		holidays = append(holidays, Holiday{Date: time.Date(d.year, d.month, d.day, 0, 0, 0, 0, time.UTC), Name: name})
+	}
+	sort.Slice(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+	return holidays
+}
+
+// IsWeekend checks if the day of t is a weekend day
+func (c *StaticHolidayCalendar) IsWeekend(t time.Time) bool {
+	return c.weekend[t.Weekday()]
+}
+
+// HolidayName returns the name of the holiday on the day of t
+func (c *StaticHolidayCalendar) HolidayName(t time.Time) (string, bool) {
+	name, ok := c.holidays[civilDateOf(t)]
+	return name, ok
+}
+
+// IsHoliday checks if the day of t is a holiday
+func (c *StaticHolidayCalendar) IsHoliday(t time.Time) bool {
+	_, ok := c.HolidayName(t)
+	return ok
+}
+
+// IsBusinessDay checks if the day of t is neither a weekend day nor a holiday
+func (c *StaticHolidayCalendar) IsBusinessDay(t time.Time) bool {
+	return !c.IsWeekend(t) && !c.IsHoliday(t)
+}
+
+// LoadICS adds all events of the given iCalendar (.ics) data as holidays
+func (c *StaticHolidayCalendar) LoadICS(data []byte) error {
+	holidays, err := ParseICSHolidays(data)
+	if err != nil {
+		return err
+	}
+
+	c.AddHolidays(holidays...)
+	return nil
+}
+
+// LoadICSFile adds all events of the given iCalendar (.ics) file as holidays
+func (c *StaticHolidayCalendar) LoadICSFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return c.LoadICS(data)
+}
+
+func calendarOrDefault(cal HolidayCalendar) HolidayCalendar {
+	if cal == nil {
+		return DefaultHolidayCalendar
+	}
+	return cal
+}
+
+// AddBusinessDays moves t by n business days keeping the time of the day.
+// Negative n moves t backwards. If nil calendar is given, DefaultHolidayCalendar is used.
+// The zero time is returned if there is no business day within 10 years (e.g. every day is a weekend day).
+func AddBusinessDays(t time.Time, n int, cal HolidayCalendar) time.Time {
+	cal = calendarOrDefault(cal)
+
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+
+	for skipped := 0; n > 0; {
+		if skipped >= maxNonBusinessDays {
+			return time.Time{}
+		}
+		t = t.AddDate(0, 0, step)
+		if cal.IsBusinessDay(t) {
+			n--
+			skipped = 0
+		} else {
+			skipped++
+		}
+	}
+
+	return t
+}
+
+// TruncateToBusinessDay returns the start of t's day if it's a business day,
+// otherwise the start of the closest business day before t (the zero time if there is none, see AddBusinessDays)
+func TruncateToBusinessDay(t time.Time, cal HolidayCalendar) time.Time {
+	cal = calendarOrDefault(cal)
+
+	day := TruncateToDay(t)
+	if cal.IsBusinessDay(day) {
+		return day
+	}
+	return AddBusinessDays(day, -1, cal)
+}
+
+// PreviousBusinessDay returns the start of the closest business day before t's day
+func PreviousBusinessDay(t time.Time, cal HolidayCalendar) time.Time {
+	return AddBusinessDays(TruncateToDay(t), -1, cal)
+}
+
+// NextBusinessDay returns the start of the closest business day after t's day
+func NextBusinessDay(t time.Time, cal HolidayCalendar) time.Time {
+	return AddBusinessDays(TruncateToDay(t), 1, cal)
+}
+
+// BusinessDayUnitDefinition returns a definition of the business-day unit ("bd") using the given calendar.
+// It can be used to replace the built-in one (based on DefaultHolidayCalendar) in a UnitRegistry.
+func BusinessDayUnitDefinition(cal HolidayCalendar) UnitDefinition {
+	return UnitDefinition{
+		Unit: UnitBusinessDay,
+		AddFunc: func(t time.Time, value float64) time.Time {
+			return AddBusinessDays(t, int(value), cal)
+		},
+		TruncateFunc: func(t time.Time) time.Time {
+			return TruncateToBusinessDay(t, cal)
+		},
+	}
+}
+
+// GetBusinessDayAliases returns aliases that are based on the given calendar
+func GetBusinessDayAliases(cal HolidayCalendar) []Alias {
+	return []Alias{
+		{
+			Slug:        "this-business-day",
+			Description: "Time of the start of today if it's a business day, otherwise of the last business day",
+			Callback: func(now time.Time) time.Time {
+				return TruncateToBusinessDay(now, cal)
+			},
+		},
+		{
+			Slug:        "last-business-day",
+			Description: "Time of the start of the previous business day",
+			Callback: func(now time.Time) time.Time {
+				return PreviousBusinessDay(now, cal)
+			},
+		},
+		{
+			Slug:        "next-business-day",
+			Description: "Time of the start of the next business day",
+			Callback: func(now time.Time) time.Time {
+				return NextBusinessDay(now, cal)
+			},
+		},
+	}
+}
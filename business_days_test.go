@@ -0,0 +1,167 @@
+package epoch_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aahainc/epoch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Business days", func() {
+	// 2023-12-22 is Friday
+	friday := time.Date(2023, time.December, 22, 14, 30, 0, 0, time.UTC)
+	saturday := time.Date(2023, time.December, 23, 10, 0, 0, 0, time.UTC)
+
+	var cal *epoch.StaticHolidayCalendar
+	BeforeEach(func() {
+		cal = epoch.NewStaticHolidayCalendar().
+			AddHoliday(time.Date(2023, time.December, 25, 0, 0, 0, 0, time.UTC), "Christmas Day").
+			AddHoliday(time.Date(2023, time.December, 26, 0, 0, 0, 0, time.UTC), "Boxing Day")
+	})
+
+	Context("StaticHolidayCalendar", func() {
+		It("treats weekends and holidays as non-business days", func() {
+			Expect(cal.IsBusinessDay(friday)).To(BeTrue())
+			Expect(cal.IsBusinessDay(saturday)).To(BeFalse())
+			Expect(cal.IsBusinessDay(time.Date(2023, time.December, 25, 12, 0, 0, 0, time.UTC))).To(BeFalse())
+
+			name, ok := cal.HolidayName(time.Date(2023, time.December, 26, 23, 0, 0, 0, time.UTC))
+			Expect(ok).To(BeTrue())
+			Expect(name).To(Equal("Boxing Day"))
+		})
+
+		It("supports custom weekends", func() {
+			cal.SetWeekend(time.Friday, time.Saturday)
+			Expect(cal.IsBusinessDay(friday)).To(BeFalse())
+			Expect(cal.IsBusinessDay(time.Date(2023, time.December, 24, 0, 0, 0, 0, time.UTC))).To(BeTrue())
+		})
+	})
+
+	Context("AddBusinessDays", func() {
+		DescribeTable("moves time by business days", func(t time.Time, n int, expected string) {
+			Expect(epoch.AddBusinessDays(t, n, cal).String()).To(Equal(expected))
+		},
+			Entry("over a weekend and holidays", friday, 1, "2023-12-27 14:30:00 +0000 UTC"),
+			Entry("several days", friday, 5, "2024-01-02 14:30:00 +0000 UTC"),
+			Entry("backwards", time.Date(2023, time.December, 27, 9, 0, 0, 0, time.UTC), -1, "2023-12-22 09:00:00 +0000 UTC"),
+			Entry("from a weekend", saturday, 1, "2023-12-27 10:00:00 +0000 UTC"),
+			Entry("zero days", saturday, 0, "2023-12-23 10:00:00 +0000 UTC"),
+		)
+
+		It("uses weekends only when no calendar is given", func() {
+			Expect(epoch.AddBusinessDays(friday, 1, nil).String()).To(Equal("2023-12-25 14:30:00 +0000 UTC"))
+		})
+
+		It("gives up on calendars without business days", func() {
+			c := epoch.NewStaticHolidayCalendar().SetWeekend(0, 1, 2, 3, 4, 5, 6)
+			Expect(epoch.AddBusinessDays(friday, 1, c).IsZero()).To(BeTrue())
+			Expect(epoch.AddBusinessDays(friday, -2, c).IsZero()).To(BeTrue())
+			Expect(epoch.TruncateToBusinessDay(friday, c).IsZero()).To(BeTrue())
+			Expect(epoch.AddBusinessDays(friday, 0, c)).To(Equal(friday))
+		})
+	})
+
+	Context("Truncation", func() {
+		It("truncates to the start of a business day", func() {
+			Expect(epoch.TruncateToBusinessDay(friday, cal).String()).To(Equal("2023-12-22 00:00:00 +0000 UTC"))
+			Expect(epoch.TruncateToBusinessDay(time.Date(2023, time.December, 26, 8, 0, 0, 0, time.UTC), cal).String()).
+				To(Equal("2023-12-22 00:00:00 +0000 UTC"))
+		})
+
+		It("finds previous and next business days", func() {
+			wednesday := time.Date(2023, time.December, 27, 8, 0, 0, 0, time.UTC)
+			Expect(epoch.PreviousBusinessDay(wednesday, cal).String()).To(Equal("2023-12-22 00:00:00 +0000 UTC"))
+			Expect(epoch.NextBusinessDay(friday, cal).String()).To(Equal("2023-12-27 00:00:00 +0000 UTC"))
+		})
+	})
+
+	Context("Business day unit", func() {
+		It("parses and adds business days", func() {
+			i, err := epoch.ParseInterval("5bd")
+			Expect(err).Should(Succeed())
+			Expect(i.Unit).To(Equal(epoch.UnitBusinessDay))
+			Expect(i.IsSafeDuration()).To(BeFalse())
+			Expect(epoch.TimeAddInterval(friday, i).String()).To(Equal("2023-12-29 14:30:00 +0000 UTC"))
+		})
+
+		It("uses a custom calendar in a registry", func() {
+			units := epoch.NewDefaultUnitRegistry()
+			Expect(units.Replace(epoch.BusinessDayUnitDefinition(cal))).Should(Succeed())
+			Expect(units.AddInterval(friday, epoch.MustParseInterval("1bd")).String()).To(Equal("2023-12-27 14:30:00 +0000 UTC"))
+		})
+	})
+
+	Context("Aliases", func() {
+		It("resolves business day aliases", func() {
+			p := epoch.NewTimeParser(epoch.WithParsers(
+				epoch.NewAliasesParser().SetClock(epoch.NewStaticClock(time.Date(2023, time.December, 27, 8, 0, 0, 0, time.UTC))).SetHolidayCalendar(cal),
+			))
+
+			t, err := p.Parse("last-business-day", time.UTC)
+			Expect(err).Should(Succeed())
+			Expect(t.String()).To(Equal("2023-12-22 00:00:00 +0000 UTC"))
+
+			t, err = p.Parse("next-business-day", time.UTC)
+			Expect(err).Should(Succeed())
+			Expect(t.String()).To(Equal("2023-12-28 00:00:00 +0000 UTC"))
+
+			t, err = p.Parse("this-business-day", time.UTC)
+			Expect(err).Should(Succeed())
+			Expect(t.String()).To(Equal("2023-12-27 00:00:00 +0000 UTC"))
+		})
+	})
+
+	Context("iCalendar", func() {
+		ics := "BEGIN:VCALENDAR\r\n" +
+			"VERSION:2.0\r\n" +
+			"BEGIN:VEVENT\r\n" +
+			"DTSTART;VALUE=DATE:20240101\r\n" +
+			"SUMMARY:New Year\\, observed\r\n" +
+			"END:VEVENT\r\n" +
+			"BEGIN:VEVENT\r\n" +
+			"DTSTART;VALUE=DATE:20241224\r\n" +
+			"DTEND;VALUE=DATE:20241227\r\n" +
+			"SUMMARY:Christmas \r\n" +
+			" holidays\r\n" +
+			"END:VEVENT\r\n" +
+			"BEGIN:VEVENT\r\n" +
+			"DTSTART;TZID=Europe/Berlin:20240501T000000\r\n" +
+			"DTEND;TZID=Europe/Berlin:20240501T235959\r\n" +
+			"SUMMARY:Labour Day\r\n" +
+			"END:VEVENT\r\n" +
+			"END:VCALENDAR\r\n"
+
+		It("parses holidays", func() {
+			holidays, err := epoch.ParseICSHolidays([]byte(ics))
+			Expect(err).Should(Succeed())
+			Expect(holidays).To(HaveLen(5))
+			Expect(holidays[0].Name).To(Equal("New Year, observed"))
+			Expect(holidays[1].Name).To(Equal("Christmas holidays"))
+			Expect(holidays[3].Date.Format("2006-01-02")).To(Equal("2024-12-26"))
+			Expect(holidays[4].Date.Format("2006-01-02")).To(Equal("2024-05-01"))
+		})
+
+		It("loads holidays from a file", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "holidays.ics")
+			Expect(os.WriteFile(path, []byte(ics), 0o600)).Should(Succeed())
+
+			c := epoch.NewStaticHolidayCalendar()
+			Expect(c.LoadICSFile(path)).Should(Succeed())
+			Expect(c.Holidays()).To(HaveLen(5))
+			Expect(c.IsBusinessDay(time.Date(2024, time.December, 25, 0, 0, 0, 0, time.UTC))).To(BeFalse())
+			Expect(c.IsBusinessDay(time.Date(2024, time.December, 27, 0, 0, 0, 0, time.UTC))).To(BeTrue())
+		})
+
+		It("rejects malformed calendars", func() {
+			_, err := epoch.ParseICSHolidays([]byte("BEGIN:VEVENT\nSUMMARY:Nothing\nEND:VEVENT\n"))
+			Expect(errors.Is(err, epoch.ErrInvalidCalendar)).To(BeTrue())
+
+			_, err = epoch.ParseICSHolidays([]byte("BEGIN:VEVENT\nDTSTART:tomorrow\nEND:VEVENT\n"))
+			Expect(errors.Is(err, epoch.ErrInvalidCalendar)).To(BeTrue())
+		})
+	})
+})
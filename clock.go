@@ -1,12 +1,32 @@
 package epoch
 
-import "time"
+import (
+	"sort"
+	"sync"
+	"time"
+)
 
 type Clock interface {
 	Now() time.Time
 }
 
+// Timer is a single-shot timer created by a TimerClock
+type Timer interface {
+	// C returns the channel the time is sent on when the timer fires
+	C() <-chan time.Time
+	// Stop prevents the timer from firing. It returns false if the timer has already fired or been stopped
+	Stop() bool
+}
+
+// TimerClock is a Clock that can also create timers, so schedulers (see AlignedTicker)
+// can be driven by a fake clock in tests
+type TimerClock interface {
+	Clock
+	NewTimer(d time.Duration) Timer
+}
+
 var _ Clock = &DefaultClock{}
+var _ TimerClock = &DefaultClock{}
 
 type DefaultClock struct{}
 
@@ -14,10 +34,27 @@ func (c *DefaultClock) Now() time.Time {
 	return time.Now()
 }
 
+// NewTimer creates a timer of the time package
+func (c *DefaultClock) NewTimer(d time.Duration) Timer {
+	return &realTimer{timer: time.NewTimer(d)}
+}
+
 func NewDefaultClock() *DefaultClock {
 	return &DefaultClock{}
 }
 
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t *realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t *realTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
 type StaticClock struct {
 	fixedTime time.Time
 }
@@ -31,3 +68,108 @@ func (c *StaticClock) Now() time.Time {
 func NewStaticClock(fixedTime time.Time) *StaticClock {
 	return &StaticClock{fixedTime: fixedTime}
 }
+
+// FakeClock is a TimerClock whose time moves only when it's told to (see Advance and Set).
+// Timers fire when the time reaches them. It's safe for concurrent use.
+type FakeClock struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*fakeTimer
+}
+
+var _ TimerClock = &FakeClock{}
+
+// NewFakeClock returns a FakeClock set to the given time
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// NewTimer creates a timer firing when the clock is moved by d
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{clock: c, at: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- c.now
+		return t
+	}
+
+	c.timers = append(c.timers, t)
+	c.cond.Broadcast()
+	return t
+}
+
+// Advance moves the clock forward by d firing all the timers that are due
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set sets the time of the clock firing all the timers that are due (in order of their time)
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = t
+	sort.SliceStable(c.timers, func(i, j int) bool {
+		return c.timers[i].at.Before(c.timers[j].at)
+	})
+
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.at.After(t) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.c <- t
+	}
+	c.timers = pending
+}
+
+// BlockUntil blocks until at least n timers are waiting for the clock,
+// so a test can be sure a scheduler is waiting before moving the clock
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+func (c *FakeClock) stop(t *fakeTimer) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, timer := range c.timers {
+		if timer == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTimer struct {
+	clock *FakeClock
+	at    time.Time
+	c     chan time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	return t.clock.stop(t)
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aahainc/epoch"
+)
+
+// timeFlags are flags shared by commands parsing times
+type timeFlags struct {
+	tz      string
+	format  string
+	now     string
+	parsers string
+}
+
+func (f *timeFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.tz, "tz", "", "location times are parsed and printed in, e.g. Asia/Tokyo or +05:30 (local by default)")
+	fs.StringVar(&f.format, "format", time.RFC3339Nano, "Go layout of printed times, or unix, unix-milli")
+	fs.StringVar(&f.now, "now", "", "current time in RFC3339 (the system time by default)")
+	fs.StringVar(&f.parsers, "parsers", "base,unix-milli,unix-seconds,aliases", "comma-separated parsers to try in order")
+}
+
+// parser returns the time parser and the location given by the flags
+func (f *timeFlags) parser(extra ...epoch.TimeParserOption) (*epoch.TimeParser, *time.Location, error) {
+	loc := time.Local
+	if f.tz != "" {
+		var err error
+		if loc, err = epoch.ParseLocation(f.tz); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	parsers, err := epoch.NewParsersByName(splitList(f.parsers)...)
+	if err != nil {
+		return nil, nil, err
+	}
+	options := append([]epoch.TimeParserOption{epoch.WithIntervalArithmetics(), epoch.WithParsers(parsers...)}, extra...)
+
+	if f.now != "" {
+		now, err := time.Parse(time.RFC3339Nano, f.now)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid --now: %w", err)
+		}
+		options = append(options, epoch.WithClock(epoch.NewFakeClock(now)))
+	}
+
+	return epoch.NewTimeParser(options...), loc, nil
+}
+
+func (f *timeFlags) formatTime(t time.Time) string {
+	switch f.format {
+	case "unix":
+		return strconv.FormatInt(t.Unix(), 10)
+	case "unix-milli":
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	default:
+		return t.Format(f.format)
+	}
+}
+
+// explanation is the output of "parse --explain"
+type explanation struct {
+	Input     string              `json:"input"`
+	Time      string              `json:"time"`
+	UnixMilli int64               `json:"unix_milli"`
+	Details   *epoch.ParseDetails `json:"details"`
+}
+
+func runParse(args []string, stdout, stderr io.Writer) error {
+	var flags timeFlags
+	var explain, trace bool
+	fs := newFlagSet("parse", "<expression>...", stderr)
+	flags.register(fs)
+	fs.BoolVar(&explain, "explain", false, "print parse details as JSON")
+	fs.BoolVar(&trace, "trace", false, "print how the time is computed step by step (included in --explain)")
+
+	exprs, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(exprs) == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	var options []epoch.TimeParserOption
+	if trace {
+		options = append(options, epoch.WithTrace())
+	}
+	tp, loc, err := flags.parser(options...)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	for _, expr := range exprs {
+		t, details, err := tp.ParseExt(expr, loc)
+		if err != nil {
+			return fmt.Errorf("%q: %w", expr, err)
+		}
+		t = t.In(loc)
+
+		if !explain {
+			if trace {
+				fmt.Fprint(stdout, details.Trace)
+			}
+			fmt.Fprintln(stdout, flags.formatTime(t))
+			continue
+		}
+		if err := enc.Encode(explanation{Input: expr, Time: flags.formatTime(t), UnixMilli: t.UnixMilli(), Details: details}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runInterval(args []string, stdout, stderr io.Writer) error {
+	var to string
+	var humanize bool
+	fs := newFlagSet("interval", "<interval>...", stderr)
+	fs.StringVar(&to, "to", "", "unit to convert intervals into, e.g. h or mo (intervals are normalized by default)")
+	fs.BoolVar(&humanize, "humanize", false, "print intervals in words, e.g. 1.5 hours")
+
+	intervals, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(intervals) == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	units := epoch.NewDefaultUnitRegistry()
+	for _, s := range intervals {
+		i, err := units.ParseInterval(s)
+		if err != nil {
+			return fmt.Errorf("%q: %w", s, err)
+		}
+
+		if to == "" {
+			i = units.Normalize(i)
+		} else {
+			def, ok := units.Lookup(to)
+			if !ok {
+				return fmt.Errorf("%w: %s", epoch.ErrInvalidUnit, to)
+			}
+			if i, err = units.Convert(i, def.Unit); err != nil {
+				return err
+			}
+		}
+
+		if humanize {
+			fmt.Fprintln(stdout, units.Humanize(i))
+		} else {
+			fmt.Fprintln(stdout, i)
+		}
+	}
+	return nil
+}
+
+func runRange(args []string, stdout, stderr io.Writer) error {
+	var flags timeFlags
+	var from, to, step string
+	var points int
+	fs := newFlagSet("range", "", stderr)
+	flags.register(fs)
+	fs.StringVar(&from, "from", "", "start of the range (required)")
+	fs.StringVar(&to, "to", "now", "end of the range")
+	fs.StringVar(&step, "step", "", "bucket size, e.g. 5m or 1mo (chosen by --points if not given)")
+	fs.IntVar(&points, "points", 100, "max number of buckets (0 means no limit for --step)")
+
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if from == "" {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	tp, loc, err := flags.parser()
+	if err != nil {
+		return err
+	}
+
+	start, err := tp.Parse(from, loc)
+	if err != nil {
+		return fmt.Errorf("--from: %w", err)
+	}
+	end, err := tp.Parse(to, loc)
+	if err != nil {
+		return fmt.Errorf("--to: %w", err)
+	}
+	r := epoch.NewRange(start, end)
+
+	var i *epoch.Interval
+	if step != "" {
+		if i, err = epoch.ParseInterval(step); err != nil {
+			return fmt.Errorf("--step: %w", err)
+		}
+		// a single allowed step checks the number of buckets before building them
+		if points > 0 {
+			if _, err = epoch.AutoStep(r, points, []*epoch.Interval{i}, epoch.WithScheduleLocation(loc)); err != nil {
+				return fmt.Errorf("--step: %w", err)
+			}
+		}
+	} else if i, err = epoch.AutoStep(r, points, nil, epoch.WithScheduleLocation(loc)); err != nil {
+		return err
+	}
+
+	buckets, err := epoch.Buckets(nil, r, i, epoch.WithScheduleLocation(loc))
+	if err != nil {
+		return err
+	}
+	for _, b := range buckets {
+		fmt.Fprintf(stdout, "%s/%s\n", flags.formatTime(b.Start), flags.formatTime(b.End))
+	}
+	return nil
+}
+
+func runAliases(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("aliases", "", stderr)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	for _, alias := range epoch.NewAliasesParser().GetDictionary() {
+		fmt.Fprintf(w, "%s\t%s\n", alias.Slug, alias.Description)
+	}
+	return w.Flush()
+}
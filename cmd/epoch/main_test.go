@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("epoch", func() {
+	exec := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := run(args, &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	DescribeTable("commands", func(args []string, expected string) {
+		code, stdout, stderr := exec(args...)
+		Expect(code).To(Equal(0), stderr)
+		Expect(stdout).To(Equal(expected))
+	},
+		Entry("parse in a location", []string{"parse", "--tz", "Asia/Tokyo", "--now", "2024-01-10T12:00:00Z", "yesterday,-6h"},
+			"2024-01-08T18:00:00+09:00\n"),
+		Entry("parse unix milliseconds", []string{"parse", "--tz", "UTC", "1704067200000"}, "2024-01-01T00:00:00Z\n"),
+		Entry("parse with flags after arguments", []string{"parse", "now,-1h", "--now", "2024-01-10T12:00:00Z", "--tz", "UTC", "--format", "unix"},
+			"1704884400\n"),
+		Entry("normalize intervals", []string{"interval", "90m", "120m", "12mo"}, "90m\n2h\n1y\n"),
+		Entry("convert intervals", []string{"interval", "--to", "h", "90m"}, "1.5h\n"),
+		Entry("humanize intervals", []string{"interval", "--humanize", "--to", "d", "2w"}, "14 days\n"),
+		Entry("split a range", []string{"range", "--tz", "UTC", "--from", "2024-01-01T00:00:00Z", "--to", "2024-03-15T00:00:00Z", "--step", "1mo", "--format", "2006-01-02"},
+			"2024-01-01/2024-02-01\n2024-02-01/2024-03-01\n2024-03-01/2024-04-01\n"),
+		Entry("choose the step of a range", []string{"range", "--tz", "UTC", "--now", "2024-01-10T12:00:00Z", "--from", "now,-1d", "--points", "2", "--format", "15:04"},
+			"12:00/00:00\n00:00/12:00\n"),
+		Entry("split a range without a limit of buckets", []string{"range", "--tz", "UTC", "--now", "2024-01-10T12:00:00Z", "--from", "now,-3h", "--step", "1h", "--points", "0", "--format", "15:04"},
+			"09:00/10:00\n10:00/11:00\n11:00/12:00\n"),
+	)
+
+	It("explains parsing as JSON", func() {
+		code, stdout, _ := exec("parse", "--explain", "--tz", "UTC", "--now", "2024-01-10T12:00:00Z", "today,+1h")
+		Expect(code).To(Equal(0))
+
+		var e map[string]interface{}
+		Expect(json.Unmarshal([]byte(stdout), &e)).To(Succeed())
+		Expect(e["time"]).To(Equal("2024-01-10T01:00:00Z"))
+		Expect(e["details"]).To(HaveKeyWithValue("parser_name", "aliases"))
+		Expect(e["details"]).To(HaveKey("arithmetics"))
+	})
+
+	It("traces parsing", func() {
+		code, stdout, _ := exec("parse", "--trace", "--tz", "UTC", "--now", "2024-01-10T12:00:00Z", "today,-1d")
+		Expect(code).To(Equal(0))
+		Expect(stdout).To(Equal("input:    today,-1d\n" +
+			"location: UTC (argument)\n" +
+			"1. anchor    today  ->  2024-01-10T00:00:00Z (aliases)\n" +
+			"2. interval  -1d    2024-01-10T00:00:00Z  ->  2024-01-09T00:00:00Z\n" +
+			"result:   2024-01-09T00:00:00Z\n" +
+			"2024-01-09T00:00:00Z\n"))
+	})
+
+	It("lists aliases", func() {
+		code, stdout, _ := exec("aliases")
+		Expect(code).To(Equal(0))
+		Expect(stdout).To(MatchRegexp(`(?m)^yesterday\s+Time of the start of yesterday$`))
+	})
+
+	DescribeTable("errors", func(args []string, expectedCode int, expected string) {
+		code, _, stderr := exec(args...)
+		Expect(code).To(Equal(expectedCode))
+		Expect(stderr).To(ContainSubstring(expected))
+	},
+		Entry("no command", []string{}, 2, "usage: epoch"),
+		Entry("unknown command", []string{"format"}, 2, `unknown command "format"`),
+		Entry("invalid expression", []string{"parse", "someday"}, 1, `epoch parse: "someday"`),
+		Entry("invalid location", []string{"parse", "--tz", "Mars/Olympus", "now"}, 1, "invalid location"),
+		Entry("incompatible units", []string{"interval", "--to", "d", "1mo"}, 1, "incompatible units"),
+		Entry("unknown unit", []string{"interval", "--to", "x", "1h"}, 1, "invalid unit"),
+		Entry("missing arguments", []string{"parse"}, 2, "usage: epoch parse"),
+		Entry("unknown flag", []string{"range", "--since", "now"}, 2, "flag provided but not defined"),
+		Entry("too many buckets", []string{"range", "--from", "now,-10y", "--step", "1s"}, 1, "too many points"),
+	)
+})
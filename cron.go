@@ -0,0 +1,480 @@
+package epoch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCron = fmt.Errorf("invalid cron expression")
+)
+
+// maxCronDays limits the search of fire times (e.g. "0 0 30 2 *" never fires)
+const maxCronDays = 10 * 366
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var cronWeekdayNames = map[string]int{
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+}
+
+// cronBounds are bounds of a cron field
+type cronBounds struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronSecond  = cronBounds{name: "second", min: 0, max: 59}
+	cronMinute  = cronBounds{name: "minute", min: 0, max: 59}
+	cronHour    = cronBounds{name: "hour", min: 0, max: 23}
+	cronDom     = cronBounds{name: "day of month", min: 1, max: 31}
+	cronMonth   = cronBounds{name: "month", min: 1, max: 12, names: cronMonthNames}
+	cronWeekday = cronBounds{name: "day of week", min: 0, max: 7, names: cronWeekdayNames}
+)
+
+// cronNthWeekday is the n-th given weekday of the month (e.g. "5#3" is the third Friday), n < 0 means the last one
+type cronNthWeekday struct {
+	weekday time.Weekday
+	n       int
+}
+
+var _ Schedule = &CronSchedule{}
+
+// CronSchedule is a schedule given by a cron expression. It's immutable and safe for concurrent use.
+//
+// Both 5-field ("min hour dom month dow") and 6-field ("sec min hour dom month dow") expressions are supported,
+// as well as macros (@yearly, @monthly, @weekly, @daily, @hourly), names of months and weekdays,
+// and extensions: "L" (last day of month), "L-3" (3 days before it), "15W" (the weekday nearest to the 15th),
+// "LW" (the last weekday), "5L" (the last Friday) and "5#3" (the third Friday).
+//
+// As in Vixie cron, if both day of month and day of week are restricted, a day matching either of them fires.
+// Fire times are computed on the wall clock of the location: times skipped by DST don't fire,
+// times repeated by DST fire once (at the first occurrence).
+type CronSchedule struct {
+	source   string
+	location *time.Location
+
+	seconds, minutes, hours, months uint64
+	daysOfMonth, weekdays           uint64
+	domAny, weekdayAny              bool
+
+	lastDayOffsets  []int
+	nearestWeekdays []int
+	lastWeekday     bool
+	nthWeekdays     []cronNthWeekday
+}
+
+// ParseCron parses a cron expression evaluated in the given location (UTC if nil is given).
+// The location can be overridden by a "CRON_TZ=Europe/Berlin " or "TZ=Europe/Berlin " prefix.
+func ParseCron(expr string, loc *time.Location) (*CronSchedule, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	source := expr
+	expr = strings.TrimSpace(expr)
+	for _, prefix := range []string{"CRON_TZ=", "TZ="} {
+		if !strings.HasPrefix(expr, prefix) {
+			continue
+		}
+		parts := strings.SplitN(expr[len(prefix):], " ", 2)
+		var err error
+		loc, err = ParseLocation(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCron, source)
+		}
+		expr = strings.TrimSpace(parts[1])
+	}
+
+	if strings.HasPrefix(expr, "@") {
+		macro, ok := cronMacros[strings.ToLower(expr)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown macro %s", ErrInvalidCron, expr)
+		}
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("%w: expected 5 or 6 fields, got %d in %q", ErrInvalidCron, len(fields), source)
+	}
+
+	c := &CronSchedule{source: source, location: loc}
+	var err error
+	if c.seconds, err = parseCronField(fields[0], cronSecond); err != nil {
+		return nil, err
+	}
+	if c.minutes, err = parseCronField(fields[1], cronMinute); err != nil {
+		return nil, err
+	}
+	if c.hours, err = parseCronField(fields[2], cronHour); err != nil {
+		return nil, err
+	}
+	if err = c.parseDaysOfMonth(fields[3]); err != nil {
+		return nil, err
+	}
+	if c.months, err = parseCronField(fields[4], cronMonth); err != nil {
+		return nil, err
+	}
+	if err = c.parseWeekdays(fields[5]); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// MustParseCron is like ParseCron but panics on error
+func MustParseCron(expr string, loc *time.Location) *CronSchedule {
+	c, err := ParseCron(expr, loc)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// parseCronField parses a list of values, ranges and steps, e.g. "1,5-10,*/15", into a bit set
+func parseCronField(field string, b cronBounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		partBits, err := parseCronRange(part, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= partBits
+	}
+	return bits, nil
+}
+
+func parseCronRange(part string, b cronBounds) (uint64, error) {
+	invalid := func() (uint64, error) {
+		return 0, fmt.Errorf("%w: invalid %s %q", ErrInvalidCron, b.name, part)
+	}
+
+	rng, step := part, 1
+	if i := strings.Index(part, "/"); i >= 0 {
+		var err error
+		rng = part[:i]
+		step, err = strconv.Atoi(part[i+1:])
+		if err != nil || step <= 0 {
+			return invalid()
+		}
+	}
+
+	var from, to int
+	switch {
+	case rng == "*" || rng == "?":
+		from, to = b.min, b.max
+	case strings.Contains(rng, "-"):
+		bounds := strings.SplitN(rng, "-", 2)
+		var ok1, ok2 bool
+		from, ok1 = parseCronValue(bounds[0], b)
+		to, ok2 = parseCronValue(bounds[1], b)
+		if !ok1 || !ok2 || from > to {
+			return invalid()
+		}
+	default:
+		var ok bool
+		from, ok = parseCronValue(rng, b)
+		if !ok {
+			return invalid()
+		}
+		to = from
+		// "5/15" means "5-max/15"
+		if strings.Contains(part, "/") {
+			to = b.max
+		}
+	}
+
+	var bits uint64
+	for v := from; v <= to; v += step {
+		bits |= 1 << uint(v)
+	}
+	return bits, nil
+}
+
+func parseCronValue(s string, b cronBounds) (int, bool) {
+	if v, ok := b.names[strings.ToUpper(s)]; ok {
+		return v, true
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < b.min || v > b.max {
+		return 0, false
+	}
+	return v, true
+}
+
+func (c *CronSchedule) parseDaysOfMonth(field string) error {
+	c.domAny = strings.HasPrefix(field, "*") || field == "?"
+
+	for _, part := range strings.Split(field, ",") {
+		invalid := fmt.Errorf("%w: invalid %s %q", ErrInvalidCron, cronDom.name, part)
+		upper := strings.ToUpper(part)
+		switch {
+		case upper == "L":
+			c.lastDayOffsets = append(c.lastDayOffsets, 0)
+		case strings.HasPrefix(upper, "L-"):
+			n, err := strconv.Atoi(upper[2:])
+			if err != nil || n < 0 || n > 30 {
+				return invalid
+			}
+			c.lastDayOffsets = append(c.lastDayOffsets, n)
+		case upper == "LW":
+			c.lastWeekday = true
+		case strings.HasSuffix(upper, "W"):
+			n, ok := parseCronValue(upper[:len(upper)-1], cronDom)
+			if !ok {
+				return invalid
+			}
+			c.nearestWeekdays = append(c.nearestWeekdays, n)
+		default:
+			bits, err := parseCronRange(part, cronDom)
+			if err != nil {
+				return err
+			}
+			c.daysOfMonth |= bits
+		}
+	}
+	return nil
+}
+
+func (c *CronSchedule) parseWeekdays(field string) error {
+	c.weekdayAny = strings.HasPrefix(field, "*") || field == "?"
+
+	for _, part := range strings.Split(field, ",") {
+		invalid := fmt.Errorf("%w: invalid %s %q", ErrInvalidCron, cronWeekday.name, part)
+		upper := strings.ToUpper(part)
+		switch {
+		case strings.Contains(upper, "#"):
+			kv := strings.SplitN(upper, "#", 2)
+			w, ok := parseCronValue(kv[0], cronWeekday)
+			n, err := strconv.Atoi(kv[1])
+			if !ok || err != nil || n < 1 || n > 5 {
+				return invalid
+			}
+			c.nthWeekdays = append(c.nthWeekdays, cronNthWeekday{weekday: time.Weekday(w % 7), n: n})
+		case len(upper) > 1 && strings.HasSuffix(upper, "L"):
+			w, ok := parseCronValue(upper[:len(upper)-1], cronWeekday)
+			if !ok {
+				return invalid
+			}
+			c.nthWeekdays = append(c.nthWeekdays, cronNthWeekday{weekday: time.Weekday(w % 7), n: -1})
+		default:
+			bits, err := parseCronRange(part, cronWeekday)
+			if err != nil {
+				return err
+			}
+			c.weekdays |= bits
+		}
+	}
+
+	// both 0 and 7 are Sunday
+	if c.weekdays&(1<<7) != 0 {
+		c.weekdays |= 1
+	}
+	return nil
+}
+
+// String returns the expression the schedule was parsed from
+func (c *CronSchedule) String() string {
+	return c.source
+}
+
+// Location returns the location the schedule is evaluated in
+func (c *CronSchedule) Location() *time.Location {
+	return c.location
+}
+
+// Next returns the first fire time after t (zero time if the schedule never fires)
+func (c *CronSchedule) Next(t time.Time) time.Time {
+	return c.search(t, true)
+}
+
+// Prev returns the last fire time before t (zero time if there is none)
+func (c *CronSchedule) Prev(t time.Time) time.Time {
+	return c.search(t, false)
+}
+
+func (c *CronSchedule) search(t time.Time, forward bool) time.Time {
+	t = t.In(c.location)
+	y, m, d := t.Date()
+	wall := [3]int{t.Hour(), t.Minute(), t.Second()}
+
+	step := 1
+	if !forward {
+		step = -1
+	}
+
+	// days are iterated on the civil calendar, so DST doesn't affect them
+	start := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < maxCronDays; i++ {
+		day := start.AddDate(0, 0, i*step)
+		if !c.matchDay(day) {
+			continue
+		}
+		if fire, ok := c.searchDay(day, i == 0, wall, t, forward); ok {
+			return fire
+		}
+	}
+
+	return time.Time{}
+}
+
+// searchDay returns the first (or the last if !forward) fire time of the day after (before) t
+func (c *CronSchedule) searchDay(day time.Time, sameDay bool, wall [3]int, t time.Time, forward bool) (time.Time, bool) {
+	behind := func(v, w int) bool {
+		if forward {
+			return v < w
+		}
+		return v > w
+	}
+
+	for _, h := range cronValues(c.hours, 23, forward) {
+		if sameDay && behind(h, wall[0]) {
+			continue
+		}
+		sameHour := sameDay && h == wall[0]
+		for _, mi := range cronValues(c.minutes, 59, forward) {
+			if sameHour && behind(mi, wall[1]) {
+				continue
+			}
+			sameMinute := sameHour && mi == wall[1]
+			for _, s := range cronValues(c.seconds, 59, forward) {
+				if sameMinute && behind(s, wall[2]) {
+					continue
+				}
+
+				kind, instants := InspectLocalTime(time.Date(day.Year(), day.Month(), day.Day(), h, mi, s, 0, time.UTC), c.location)
+				if kind == LocalTimeNonexistent {
+					continue
+				}
+				fire := instants[0]
+				if (forward && fire.After(t)) || (!forward && fire.Before(t)) {
+					return fire, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// cronValues returns values of the bit set in ascending (or descending) order
+func cronValues(bits uint64, max int, ascending bool) []int {
+	values := make([]int, 0, max+1)
+	for v := 0; v <= max; v++ {
+		if bits&(1<<uint(v)) != 0 {
+			values = append(values, v)
+		}
+	}
+	if !ascending {
+		for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+			values[i], values[j] = values[j], values[i]
+		}
+	}
+	return values
+}
+
+// matchDay checks the month, day of month and day of week of the civil date
+func (c *CronSchedule) matchDay(day time.Time) bool {
+	if c.months&(1<<uint(day.Month())) == 0 {
+		return false
+	}
+
+	// a star-prefixed field (e.g. "*" or "*/2") is checked together with the other one,
+	// two restricted fields fire on either of them
+	if c.domAny || c.weekdayAny {
+		return c.matchDayOfMonth(day) && c.matchWeekday(day)
+	}
+	return c.matchDayOfMonth(day) || c.matchWeekday(day)
+}
+
+func (c *CronSchedule) matchDayOfMonth(day time.Time) bool {
+	d := day.Day()
+	if c.daysOfMonth&(1<<uint(d)) != 0 {
+		return true
+	}
+
+	last := daysIn(day)
+	for _, offset := range c.lastDayOffsets {
+		if d == last-offset {
+			return true
+		}
+	}
+	for _, n := range c.nearestWeekdays {
+		if n <= last && d == nearestWeekday(day, n) {
+			return true
+		}
+	}
+	if c.lastWeekday && d == nearestWeekday(day, last) {
+		return true
+	}
+	return false
+}
+
+func (c *CronSchedule) matchWeekday(day time.Time) bool {
+	w := day.Weekday()
+	if c.weekdays&(1<<uint(w)) != 0 {
+		return true
+	}
+
+	for _, nth := range c.nthWeekdays {
+		if w != nth.weekday {
+			continue
+		}
+		if nth.n < 0 && day.Day()+7 > daysIn(day) {
+			return true
+		}
+		if nth.n > 0 && (day.Day()-1)/7+1 == nth.n {
+			return true
+		}
+	}
+	return false
+}
+
+// daysIn returns the number of days in the month of the given day
+func daysIn(day time.Time) int {
+	return time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// nearestWeekday returns the day of month of the weekday (Mon-Fri) nearest to the n-th day
+// without leaving the month (as the "W" extension of cron)
+func nearestWeekday(day time.Time, n int) int {
+	target := time.Date(day.Year(), day.Month(), n, 0, 0, 0, 0, time.UTC)
+	last := daysIn(day)
+	switch target.Weekday() {
+	case time.Saturday:
+		if n == 1 {
+			return 3
+		}
+		return n - 1
+	case time.Sunday:
+		if n == last {
+			return n - 2
+		}
+		return n + 1
+	}
+	return n
+}
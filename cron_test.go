@@ -0,0 +1,138 @@
+package epoch_test
+
+import (
+	"time"
+
+	"github.com/aahainc/epoch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cron", func() {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	DescribeTable("next fire time", func(expr string, t time.Time, expected time.Time) {
+		c, err := epoch.ParseCron(expr, time.UTC)
+		Expect(err).To(Succeed())
+		Expect(c.Next(t)).To(Equal(expected))
+	},
+		Entry("every 15 minutes", "*/15 * * * *", date(2024, time.January, 1, 10, 7), date(2024, time.January, 1, 10, 15)),
+		Entry("on a fire time", "*/15 * * * *", date(2024, time.January, 1, 10, 15), date(2024, time.January, 1, 10, 30)),
+		Entry("weekdays", "0 9 * * MON-FRI", date(2024, time.January, 5, 10, 0), date(2024, time.January, 8, 9, 0)),
+		Entry("lists and steps", "5,10 8-18/5 * * *", date(2024, time.January, 1, 13, 6), date(2024, time.January, 1, 13, 10)),
+		Entry("start and step", "10/20 * * * *", date(2024, time.January, 1, 13, 31), date(2024, time.January, 1, 13, 50)),
+		Entry("6 fields", "30 * * * * *", date(2024, time.January, 1, 10, 0, 10), date(2024, time.January, 1, 10, 0, 30)),
+		Entry("months by name", "0 0 1 jan,jul *", date(2024, time.February, 1, 0, 0), date(2024, time.July, 1, 0, 0)),
+		Entry("@daily", "@daily", date(2024, time.January, 1, 10, 0), date(2024, time.January, 2, 0, 0)),
+		Entry("@hourly", "@hourly", date(2024, time.January, 1, 10, 0), date(2024, time.January, 1, 11, 0)),
+		Entry("@weekly", "@weekly", date(2024, time.January, 1, 10, 0), date(2024, time.January, 7, 0, 0)),
+		Entry("@yearly", "@yearly", date(2024, time.January, 1, 10, 0), date(2025, time.January, 1, 0, 0)),
+		Entry("Sunday as 7", "0 0 * * 7", date(2024, time.January, 1, 10, 0), date(2024, time.January, 7, 0, 0)),
+		Entry("leap day", "0 0 29 2 *", date(2024, time.March, 1, 0, 0), date(2028, time.February, 29, 0, 0)),
+		Entry("last day of month", "0 0 L * *", date(2024, time.February, 10, 0, 0), date(2024, time.February, 29, 0, 0)),
+		Entry("2 days before the last day", "0 0 L-2 * *", date(2024, time.February, 10, 0, 0), date(2024, time.February, 27, 0, 0)),
+		Entry("nearest weekday (Saturday)", "0 0 15W * *", date(2024, time.June, 1, 0, 0), date(2024, time.June, 14, 0, 0)),
+		Entry("nearest weekday (the 1st is Saturday)", "0 0 1W * *", date(2024, time.May, 31, 0, 0), date(2024, time.June, 3, 0, 0)),
+		Entry("last weekday", "0 0 LW * *", date(2024, time.August, 1, 0, 0), date(2024, time.August, 30, 0, 0)),
+		Entry("last Friday", "0 0 * * 5L", date(2024, time.January, 1, 0, 0), date(2024, time.January, 26, 0, 0)),
+		Entry("third Friday", "0 0 * * FRI#3", date(2024, time.January, 1, 0, 0), date(2024, time.January, 19, 0, 0)),
+		Entry("day of month or day of week", "0 0 13 * 5", date(2024, time.January, 1, 0, 0), date(2024, time.January, 5, 0, 0)),
+		Entry("day of week with a star day of month", "0 0 */2 * 5", date(2024, time.January, 1, 0, 0), date(2024, time.January, 5, 0, 0)),
+		Entry("day of week with a star day of month (skips even days)", "0 0 */2 * 5", date(2024, time.January, 6, 0, 0), date(2024, time.January, 19, 0, 0)),
+		Entry("every other day of month", "0 0 */2 * *", date(2024, time.January, 1, 10, 0), date(2024, time.January, 3, 0, 0)),
+		Entry("every other day of month (from an odd day)", "0 0 */2 * *", date(2024, time.January, 3, 0, 0), date(2024, time.January, 5, 0, 0)),
+		Entry("every other day of week", "0 0 * * */2", date(2024, time.January, 2, 0, 0), date(2024, time.January, 4, 0, 0)),
+		Entry("every other day of week (Saturday to Sunday)", "0 0 * * */2", date(2024, time.January, 6, 0, 0), date(2024, time.January, 7, 0, 0)),
+		Entry("day of month with a star day of week", "0 0 13 * */2", date(2024, time.January, 1, 0, 0), date(2024, time.January, 13, 0, 0)),
+	)
+
+	DescribeTable("previous fire time", func(expr string, t time.Time, expected time.Time) {
+		c, err := epoch.ParseCron(expr, time.UTC)
+		Expect(err).To(Succeed())
+		Expect(c.Prev(t)).To(Equal(expected))
+	},
+		Entry("every 15 minutes", "*/15 * * * *", date(2024, time.January, 1, 10, 7), date(2024, time.January, 1, 10, 0)),
+		Entry("on a fire time", "*/15 * * * *", date(2024, time.January, 1, 10, 15), date(2024, time.January, 1, 10, 0)),
+		Entry("weekdays", "0 9 * * MON-FRI", date(2024, time.January, 8, 8, 0), date(2024, time.January, 5, 9, 0)),
+		Entry("last day of month", "0 0 L * *", date(2024, time.March, 10, 0, 0), date(2024, time.February, 29, 0, 0)),
+	)
+
+	It("never fires on nonexistent dates", func() {
+		c := epoch.MustParseCron("0 0 30 2 *", time.UTC)
+		Expect(c.Next(date(2024, time.January, 1, 0, 0)).IsZero()).To(BeTrue())
+	})
+
+	Context("Location", func() {
+		It("fires on the wall clock of the location", func() {
+			c := epoch.MustParseCron("0 9 * * *", berlin)
+			Expect(c.Next(date(2024, time.January, 1, 0, 0))).To(BeTemporally("==", date(2024, time.January, 1, 8, 0)))
+			Expect(c.Next(date(2024, time.July, 1, 0, 0))).To(BeTemporally("==", date(2024, time.July, 1, 7, 0)))
+		})
+
+		It("takes the location from the prefix", func() {
+			c, err := epoch.ParseCron("CRON_TZ=Europe/Berlin 0 9 * * *", time.UTC)
+			Expect(err).To(Succeed())
+			Expect(c.Location().String()).To(Equal("Europe/Berlin"))
+			Expect(c.Next(date(2024, time.January, 1, 0, 0))).To(BeTemporally("==", date(2024, time.January, 1, 8, 0)))
+		})
+
+		It("skips times that don't exist when DST starts", func() {
+			c := epoch.MustParseCron("30 2 * * *", berlin)
+			Expect(c.Next(date(2024, time.March, 30, 12, 0))).To(BeTemporally("==", date(2024, time.April, 1, 0, 30)))
+		})
+
+		It("fires once at times repeated when DST ends", func() {
+			c := epoch.MustParseCron("30 2 * * *", berlin)
+			first := c.Next(date(2024, time.October, 26, 12, 0))
+			Expect(first).To(BeTemporally("==", date(2024, time.October, 27, 0, 30)))
+			Expect(c.Next(first)).To(BeTemporally("==", date(2024, time.October, 28, 1, 30)))
+		})
+	})
+
+	DescribeTable("invalid expressions", func(expr string) {
+		_, err := epoch.ParseCron(expr, time.UTC)
+		Expect(err).To(MatchError(epoch.ErrInvalidCron))
+	},
+		Entry("too few fields", "* * * *"),
+		Entry("too many fields", "* * * * * * *"),
+		Entry("out of range", "61 * * * *"),
+		Entry("reversed range", "0 5-1 * * *"),
+		Entry("zero step", "*/0 * * * *"),
+		Entry("unknown name", "0 0 * FOO *"),
+		Entry("unknown macro", "@fortnightly"),
+		Entry("invalid nth weekday", "0 0 * * 5#6"),
+		Entry("invalid nearest weekday", "0 0 32W * *"),
+	)
+
+	Context("ParseSchedule", func() {
+		It("accepts cron expressions with WithCron", func() {
+			s, err := epoch.ParseSchedule("*/15 * * * *", epoch.WithCron())
+			Expect(err).To(Succeed())
+			Expect(s.Next(date(2024, time.January, 1, 10, 7))).To(Equal(date(2024, time.January, 1, 10, 15)))
+
+			s, err = epoch.ParseSchedule("@daily", epoch.WithCron(), epoch.WithScheduleLocation(berlin))
+			Expect(err).To(Succeed())
+			Expect(s.Next(date(2024, time.January, 1, 10, 0))).To(BeTemporally("==", date(2024, time.January, 1, 23, 0)))
+
+			// intervals are still accepted
+			s, err = epoch.ParseSchedule("15m", epoch.WithCron(), epoch.WithScheduleLocation(time.UTC))
+			Expect(err).To(Succeed())
+			Expect(s.Next(date(2024, time.January, 1, 10, 7))).To(Equal(date(2024, time.January, 1, 10, 15)))
+		})
+
+		It("rejects cron expressions without WithCron", func() {
+			_, err := epoch.ParseSchedule("*/15 * * * *")
+			Expect(err).To(MatchError(epoch.ErrInvalidSchedule))
+		})
+
+		It("drives a ticker", func() {
+			clock := epoch.NewFakeClock(date(2024, time.January, 1, 10, 7))
+			ticker := epoch.NewScheduleTicker(epoch.MustParseCron("*/15 * * * *", time.UTC), clock)
+			defer ticker.Stop()
+
+			clock.BlockUntil(1)
+			clock.Set(date(2024, time.January, 1, 10, 15))
+			Eventually(ticker.C).Should(Receive(Equal(date(2024, time.January, 1, 10, 15))))
+		})
+	})
+})
@@ -0,0 +1,185 @@
+package epoch
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+var (
+	ErrNonexistentLocalTime = fmt.Errorf("nonexistent local time")
+	ErrAmbiguousLocalTime   = fmt.Errorf("ambiguous local time")
+)
+
+// Transition is a change of the UTC offset (or zone abbreviation) of a location, e.g. a DST switch
+type Transition struct {
+	// At is the first instant with the new offset
+	At time.Time `json:"at"`
+	// OffsetBefore and OffsetAfter are offsets in seconds east of UTC
+	OffsetBefore int `json:"offset_before"`
+	OffsetAfter  int `json:"offset_after"`
+	// AbbreviationBefore and AbbreviationAfter are zone abbreviations, e.g. "PST" and "PDT"
+	AbbreviationBefore string `json:"abbreviation_before"`
+	AbbreviationAfter  string `json:"abbreviation_after"`
+}
+
+// Shift returns how much the wall clock is moved by the transition.
+// It's positive when clocks are moved forward (a gap) and negative when moved back (an overlap).
+func (t Transition) Shift() time.Duration {
+	return time.Duration(t.OffsetAfter-t.OffsetBefore) * time.Second
+}
+
+// Transitions returns all transitions of the location within the range
+func Transitions(loc *time.Location, r Range) []Transition {
+	var transitions []Transition
+
+	t := r.Start.In(loc)
+	for t.Before(r.End) {
+		_, end := t.ZoneBounds()
+		// zero end means the zone lasts forever
+		if end.IsZero() || !end.Before(r.End) {
+			break
+		}
+
+		nameBefore, offsetBefore := t.Zone()
+		nameAfter, offsetAfter := end.Zone()
+		if nameBefore != nameAfter || offsetBefore != offsetAfter {
+			transitions = append(transitions, Transition{
+				At:                 end,
+				OffsetBefore:       offsetBefore,
+				OffsetAfter:        offsetAfter,
+				AbbreviationBefore: nameBefore,
+				AbbreviationAfter:  nameAfter,
+			})
+		}
+		t = end
+	}
+
+	return transitions
+}
+
+// Transitions returns all transitions within the range in the location of r.Start
+func (r Range) Transitions() []Transition {
+	return Transitions(r.Start.Location(), r)
+}
+
+// LocalTimeKind describes how a wall clock time maps to instants in a location
+type LocalTimeKind int
+
+const (
+	// LocalTimeUnique is a wall clock time that happens exactly once
+	LocalTimeUnique LocalTimeKind = iota
+	// LocalTimeNonexistent is a wall clock time skipped by a transition (e.g. 02:30 on a spring-forward day)
+	LocalTimeNonexistent
+	// LocalTimeAmbiguous is a wall clock time that happens twice (e.g. 01:30 on a fall-back day)
+	LocalTimeAmbiguous
+)
+
+func (k LocalTimeKind) String() string {
+	switch k {
+	case LocalTimeNonexistent:
+		return "nonexistent"
+	case LocalTimeAmbiguous:
+		return "ambiguous"
+	default:
+		return "unique"
+	}
+}
+
+// InspectLocalTime interprets the wall clock of the given time (its date and clock, ignoring its location) in loc.
+// It returns the kind of the local time and its candidate instants in ascending order:
+//   - one instant for unique times
+//   - two instants for ambiguous times (one for each offset)
+//   - two instants for nonexistent times, got by applying the offsets before and after the transition
+func InspectLocalTime(wall time.Time, loc *time.Location) (LocalTimeKind, []time.Time) {
+	y, mo, d := wall.Date()
+	h, mi, s := wall.Clock()
+	naive := time.Date(y, mo, d, h, mi, s, wall.Nanosecond(), time.UTC)
+
+	// a transition affecting the wall clock is always within a day around it
+	offsets := make([]int, 0, 3)
+	for _, probe := range []time.Duration{-24 * time.Hour, 0, 24 * time.Hour} {
+		_, offset := naive.Add(probe).In(loc).Zone()
+		if !containsInt(offsets, offset) {
+			offsets = append(offsets, offset)
+		}
+	}
+
+	var valid, all []time.Time
+	for _, offset := range offsets {
+		candidate := naive.Add(-time.Duration(offset) * time.Second).In(loc)
+		all = append(all, candidate)
+		if _, actual := candidate.Zone(); actual == offset {
+			valid = append(valid, candidate)
+		}
+	}
+
+	sortTimes(valid)
+	sortTimes(all)
+	switch len(valid) {
+	case 0:
+		return LocalTimeNonexistent, all
+	case 1:
+		return LocalTimeUnique, valid
+	default:
+		return LocalTimeAmbiguous, valid
+	}
+}
+
+// DSTPolicy defines how nonexistent and ambiguous wall clock times are resolved
+type DSTPolicy int
+
+const (
+	// DSTPolicyNone keeps the behavior of time.Date (the choice is not guaranteed)
+	DSTPolicyNone DSTPolicy = iota
+	// DSTPolicyEarlier picks the earlier candidate instant
+	DSTPolicyEarlier
+	// DSTPolicyLater picks the later candidate instant
+	// (for nonexistent times it's the wall clock shifted forward by the gap, e.g. 02:30 -> 03:30)
+	DSTPolicyLater
+	// DSTPolicyError fails on nonexistent and ambiguous times
+	DSTPolicyError
+)
+
+// ResolveLocalTime builds an instant from the wall clock of the given time in loc using the policy
+// (see InspectLocalTime)
+func ResolveLocalTime(wall time.Time, loc *time.Location, policy DSTPolicy) (time.Time, error) {
+	if policy == DSTPolicyNone {
+		y, mo, d := wall.Date()
+		h, mi, s := wall.Clock()
+		return time.Date(y, mo, d, h, mi, s, wall.Nanosecond(), loc), nil
+	}
+
+	kind, candidates := InspectLocalTime(wall, loc)
+	if kind == LocalTimeUnique {
+		return candidates[0], nil
+	}
+
+	switch policy {
+	case DSTPolicyEarlier:
+		return candidates[0], nil
+	case DSTPolicyLater:
+		return candidates[len(candidates)-1], nil
+	}
+
+	layout := "2006-01-02 15:04:05.999999999"
+	if kind == LocalTimeNonexistent {
+		return time.Time{}, fmt.Errorf("%w: %s in %s", ErrNonexistentLocalTime, wall.Format(layout), loc)
+	}
+	return time.Time{}, fmt.Errorf("%w: %s in %s", ErrAmbiguousLocalTime, wall.Format(layout), loc)
+}
+
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+func sortTimes(times []time.Time) {
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+}
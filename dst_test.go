@@ -0,0 +1,151 @@
+package epoch_test
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aahainc/epoch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DST", func() {
+	la, _ := time.LoadLocation("America/Los_Angeles")
+
+	Context("Transitions", func() {
+		It("lists transitions within a range", func() {
+			r := epoch.NewRange(time.Date(2019, 1, 1, 0, 0, 0, 0, la), time.Date(2020, 1, 1, 0, 0, 0, 0, la))
+			transitions := r.Transitions()
+			Expect(transitions).To(HaveLen(2))
+
+			Expect(transitions[0].At).To(BeTemporally("==", time.Date(2019, 3, 10, 10, 0, 0, 0, time.UTC)))
+			Expect(transitions[0].AbbreviationBefore).To(Equal("PST"))
+			Expect(transitions[0].AbbreviationAfter).To(Equal("PDT"))
+			Expect(transitions[0].OffsetBefore).To(Equal(-8 * 3600))
+			Expect(transitions[0].OffsetAfter).To(Equal(-7 * 3600))
+			Expect(transitions[0].Shift()).To(Equal(time.Hour))
+
+			Expect(transitions[1].At).To(BeTemporally("==", time.Date(2019, 11, 3, 9, 0, 0, 0, time.UTC)))
+			Expect(transitions[1].Shift()).To(Equal(-time.Hour))
+		})
+
+		It("returns nothing for fixed zones", func() {
+			r := epoch.NewRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+			Expect(epoch.Transitions(time.UTC, r)).To(BeEmpty())
+		})
+	})
+
+	Context("InspectLocalTime", func() {
+		It("detects unique times", func() {
+			kind, candidates := epoch.InspectLocalTime(time.Date(2019, 3, 10, 12, 0, 0, 0, time.UTC), la)
+			Expect(kind).To(Equal(epoch.LocalTimeUnique))
+			Expect(candidates).To(HaveLen(1))
+			Expect(candidates[0].String()).To(Equal("2019-03-10 12:00:00 -0700 PDT"))
+		})
+
+		It("detects nonexistent times", func() {
+			kind, candidates := epoch.InspectLocalTime(time.Date(2019, 3, 10, 2, 30, 0, 0, time.UTC), la)
+			Expect(kind).To(Equal(epoch.LocalTimeNonexistent))
+			Expect(candidates).To(HaveLen(2))
+			Expect(candidates[0].String()).To(Equal("2019-03-10 01:30:00 -0800 PST"))
+			Expect(candidates[1].String()).To(Equal("2019-03-10 03:30:00 -0700 PDT"))
+		})
+
+		It("detects ambiguous times", func() {
+			kind, candidates := epoch.InspectLocalTime(time.Date(2019, 11, 3, 1, 30, 0, 0, time.UTC), la)
+			Expect(kind).To(Equal(epoch.LocalTimeAmbiguous))
+			Expect(candidates).To(HaveLen(2))
+			Expect(candidates[0].String()).To(Equal("2019-11-03 01:30:00 -0700 PDT"))
+			Expect(candidates[1].String()).To(Equal("2019-11-03 01:30:00 -0800 PST"))
+		})
+	})
+
+	Context("ResolveLocalTime", func() {
+		ambiguous := time.Date(2019, 11, 3, 1, 30, 0, 0, time.UTC)
+		nonexistent := time.Date(2019, 3, 10, 2, 30, 0, 0, time.UTC)
+
+		It("resolves by policy", func() {
+			t, err := epoch.ResolveLocalTime(ambiguous, la, epoch.DSTPolicyEarlier)
+			Expect(err).Should(Succeed())
+			Expect(t.String()).To(Equal("2019-11-03 01:30:00 -0700 PDT"))
+
+			t, err = epoch.ResolveLocalTime(ambiguous, la, epoch.DSTPolicyLater)
+			Expect(err).Should(Succeed())
+			Expect(t.String()).To(Equal("2019-11-03 01:30:00 -0800 PST"))
+
+			t, err = epoch.ResolveLocalTime(nonexistent, la, epoch.DSTPolicyLater)
+			Expect(err).Should(Succeed())
+			Expect(t.String()).To(Equal("2019-03-10 03:30:00 -0700 PDT"))
+		})
+
+		It("fails with error policy", func() {
+			_, err := epoch.ResolveLocalTime(ambiguous, la, epoch.DSTPolicyError)
+			Expect(errors.Is(err, epoch.ErrAmbiguousLocalTime)).To(BeTrue())
+
+			_, err = epoch.ResolveLocalTime(nonexistent, la, epoch.DSTPolicyError)
+			Expect(errors.Is(err, epoch.ErrNonexistentLocalTime)).To(BeTrue())
+
+			_, err = epoch.ResolveLocalTime(time.Date(2019, 3, 10, 12, 0, 0, 0, time.UTC), la, epoch.DSTPolicyError)
+			Expect(err).Should(Succeed())
+		})
+	})
+
+	Context("BaseParser", func() {
+		format := "2006-01-02 15:04"
+
+		BeforeEach(func() {
+			epoch.BaseParserFormat = format
+		})
+		AfterEach(func() {
+			epoch.BaseParserFormat = time.RFC3339
+		})
+
+		It("applies the DST policy to parsed wall clock", func() {
+			p := epoch.NewTimeParser(epoch.WithParsers(epoch.NewBaseParser().SetDSTPolicy(epoch.DSTPolicyLater)))
+			t, err := p.Parse("2019-11-03 01:30", la)
+			Expect(err).Should(Succeed())
+			Expect(t.String()).To(Equal("2019-11-03 01:30:00 -0800 PST"))
+
+			p = epoch.NewTimeParser(epoch.WithParsers(epoch.NewBaseParser().SetDSTPolicy(epoch.DSTPolicyError)))
+			_, err = p.Parse("2019-03-10 02:30", la)
+			Expect(errors.Is(err, epoch.ErrNonexistentLocalTime)).To(BeTrue())
+		})
+
+		It("wraps parser errors once", func() {
+			p := epoch.NewTimeParser(epoch.WithParsers(epoch.NewBaseParser().SetDSTPolicy(epoch.DSTPolicyError)))
+			_, err := p.Parse("2019-03-10 02:30", la)
+			Expect(err).To(MatchError(HavePrefix("failed to parse time: ")))
+			Expect(strings.Count(err.Error(), "failed to parse time")).To(Equal(1))
+		})
+
+		It("applies the DST policy in zones at UTC+0", func() {
+			london, _ := time.LoadLocation("Europe/London")
+			p := epoch.NewTimeParser(epoch.WithParsers(epoch.NewBaseParser().SetDSTPolicy(epoch.DSTPolicyError)))
+
+			_, err := p.Parse("2023-03-26 01:30", london)
+			Expect(errors.Is(err, epoch.ErrNonexistentLocalTime)).To(BeTrue())
+			_, err = p.Parse("2023-10-29 01:30", london)
+			Expect(errors.Is(err, epoch.ErrAmbiguousLocalTime)).To(BeTrue())
+
+			t, err := p.Parse("2023-01-10 01:30", london)
+			Expect(err).To(Succeed())
+			Expect(t.String()).To(Equal("2023-01-10 01:30:00 +0000 GMT"))
+
+			p = epoch.NewTimeParser(epoch.WithParsers(epoch.NewBaseParser().SetDSTPolicy(epoch.DSTPolicyEarlier)))
+			t, err = p.Parse("2023-10-29 01:30", london)
+			Expect(err).To(Succeed())
+			Expect(t.String()).To(Equal("2023-10-29 01:30:00 +0100 BST"))
+		})
+
+		It("keeps explicit offsets", func() {
+			epoch.BaseParserFormat = time.RFC3339
+			london, _ := time.LoadLocation("Europe/London")
+			p := epoch.NewTimeParser(epoch.WithParsers(epoch.NewBaseParser().SetDSTPolicy(epoch.DSTPolicyError)))
+
+			t, err := p.Parse("2023-10-29T01:30:00Z", london)
+			Expect(err).To(Succeed())
+			Expect(t.Equal(time.Date(2023, 10, 29, 1, 30, 0, 0, time.UTC))).To(BeTrue())
+		})
+	})
+})
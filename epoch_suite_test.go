@@ -2,6 +2,7 @@ package epoch_test
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -11,3 +12,12 @@ func TestEpoch(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Epoch Suite")
 }
+
+// date returns the wall clock in UTC, seconds are optional
+func date(y int, m time.Month, d, h, min int, sec ...int) time.Time {
+	s := 0
+	if len(sec) > 0 {
+		s = sec[0]
+	}
+	return time.Date(y, m, d, h, min, s, 0, time.UTC)
+}
@@ -0,0 +1,13 @@
+package epochhttp_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestEpochHTTP(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "EpochHTTP Suite")
+}
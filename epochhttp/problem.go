@@ -0,0 +1,89 @@
+package epochhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/aahainc/epoch"
+)
+
+// ContentTypeProblem is the media type of problem details
+const ContentTypeProblem = "application/problem+json"
+
+// Problem is a problem details object of RFC 7807
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Param is the query parameter the problem is caused by (an extension member)
+	Param string `json:"param,omitempty"`
+}
+
+// problemTypes are slugs and titles of problem types by errors
+var problemTypes = []struct {
+	err   error
+	slug  string
+	title string
+}{
+	{ErrMissingParam, "missing-parameter", "Missing parameter"},
+	{ErrInvalidParam, "invalid-parameter", "Invalid parameter"},
+	{ErrInvalidWindow, "invalid-window", "Invalid time window"},
+	{ErrWindowTooLarge, "window-too-large", "Time window too large"},
+	{epoch.ErrTooManyPoints, "too-many-points", "Too many points"},
+}
+
+// Problem returns problem details of the error returned by Bind.
+// Other errors become internal server errors without details.
+func (b *Binder) Problem(r *http.Request, err error) *Problem {
+	var bindErr *Error
+	if !errors.As(err, &bindErr) {
+		return &Problem{
+			Type:     "about:blank",
+			Title:    http.StatusText(http.StatusInternalServerError),
+			Status:   http.StatusInternalServerError,
+			Instance: r.URL.Path,
+		}
+	}
+
+	p := &Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(http.StatusBadRequest),
+		Status:   http.StatusBadRequest,
+		Detail:   bindErr.Error(),
+		Instance: r.URL.Path,
+		Param:    bindErr.Param,
+	}
+	if b.problemTypes != "" {
+		for _, t := range problemTypes {
+			if errors.Is(err, t.err) {
+				p.Type, p.Title = b.problemTypes+t.slug, t.title
+				break
+			}
+		}
+	}
+	return p
+}
+
+// WriteProblem writes problem details of the error as the response
+func (b *Binder) WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	p := b.Problem(r, err)
+	w.Header().Set("Content-Type", ContentTypeProblem)
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// Handler binds the time window of each request and passes it to h.
+// Requests with invalid windows get problem details responses.
+func (b *Binder) Handler(h func(http.ResponseWriter, *http.Request, *Window)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		window, err := b.Bind(r)
+		if err != nil {
+			b.WriteProblem(w, r, err)
+			return
+		}
+		h(w, r, window)
+	})
+}
@@ -0,0 +1,217 @@
+// Package epochhttp binds time windows given by query parameters (?from=&to=&step=&tz=) of HTTP requests
+// and reports invalid ones as RFC 7807 problem details.
+package epochhttp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aahainc/epoch"
+)
+
+var (
+	ErrMissingParam   = fmt.Errorf("missing parameter")
+	ErrInvalidParam   = fmt.Errorf("invalid parameter")
+	ErrInvalidWindow  = fmt.Errorf("invalid time window")
+	ErrWindowTooLarge = fmt.Errorf("time window too large")
+)
+
+// Error is an error of a query parameter (Param is empty for errors of the whole window)
+type Error struct {
+	Param string
+	Value string
+	Err   error
+}
+
+func (e *Error) Error() string {
+	if e.Param == "" {
+		return e.Err.Error()
+	}
+	if e.Value == "" {
+		return fmt.Sprintf("%s: %s", e.Param, e.Err)
+	}
+	return fmt.Sprintf("%s=%q: %s", e.Param, e.Value, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Window is a time window bound from a request
+type Window struct {
+	Range epoch.Range
+	// Step is nil if it's neither given nor chosen automatically (see WithAutoStep)
+	Step     *epoch.Interval
+	Location *time.Location
+}
+
+// Params are names of query parameters
+type Params struct {
+	From, To, Step, TZ string
+}
+
+// Option configures a Binder
+type Option func(*Binder)
+
+// WithMaxWindow limits the length of windows
+func WithMaxWindow(d time.Duration) Option {
+	return func(b *Binder) {
+		b.maxWindow = d
+	}
+}
+
+// WithMaxPoints limits the number of aligned buckets of the step in a window (see epoch.AutoStep)
+func WithMaxPoints(n int) Option {
+	return func(b *Binder) {
+		b.maxPoints = n
+	}
+}
+
+// WithDefaults sets expressions used when from or to isn't given (by default from is required and to is "now")
+func WithDefaults(from, to string) Option {
+	return func(b *Binder) {
+		b.defaultFrom = from
+		b.defaultTo = to
+	}
+}
+
+// WithAutoStep makes the binder choose the step when it isn't given, so windows have at most max points
+// (epoch.DefaultSteps are used if no steps are given)
+func WithAutoStep(allowed ...*epoch.Interval) Option {
+	return func(b *Binder) {
+		b.autoStep = true
+		b.allowedSteps = allowed
+	}
+}
+
+// WithParams sets names of query parameters (from, to, step and tz by default)
+func WithParams(p Params) Option {
+	return func(b *Binder) {
+		b.params = p
+	}
+}
+
+// WithProblemTypes sets the base URI of problem types, e.g. "https://example.com/problems/"
+// gives "https://example.com/problems/invalid-parameter" ("about:blank" is used by default)
+func WithProblemTypes(base string) Option {
+	return func(b *Binder) {
+		b.problemTypes = base
+	}
+}
+
+// Binder binds time windows from query parameters using a TimeParser
+type Binder struct {
+	parser       *epoch.TimeParser
+	params       Params
+	maxWindow    time.Duration
+	maxPoints    int
+	defaultFrom  string
+	defaultTo    string
+	autoStep     bool
+	allowedSteps []*epoch.Interval
+	problemTypes string
+}
+
+// NewBinder returns a binder parsing times with the given parser
+func NewBinder(parser *epoch.TimeParser, options ...Option) *Binder {
+	b := &Binder{
+		parser:    parser,
+		params:    Params{From: "from", To: "to", Step: "step", TZ: "tz"},
+		defaultTo: "now",
+	}
+	for _, opt := range options {
+		opt(b)
+	}
+	return b
+}
+
+// Bind parses the time window of the request. Errors are of *Error type.
+func (b *Binder) Bind(r *http.Request) (*Window, error) {
+	q := r.URL.Query()
+	w := &Window{Location: b.parser.Location()}
+
+	if tz := q.Get(b.params.TZ); tz != "" {
+		loc, err := epoch.ParseLocation(tz)
+		if err != nil {
+			return nil, &Error{Param: b.params.TZ, Value: tz, Err: fmt.Errorf("%w: %s", ErrInvalidParam, err)}
+		}
+		w.Location = loc
+	}
+	if w.Location == nil {
+		w.Location = time.UTC
+	}
+
+	from, err := b.parseTime(q.Get(b.params.From), b.params.From, b.defaultFrom, w.Location)
+	if err != nil {
+		return nil, err
+	}
+	to, err := b.parseTime(q.Get(b.params.To), b.params.To, b.defaultTo, w.Location)
+	if err != nil {
+		return nil, err
+	}
+	w.Range = epoch.NewRange(from, to)
+
+	if w.Range.IsEmpty() {
+		return nil, &Error{Err: fmt.Errorf("%w: %s must be before %s", ErrInvalidWindow, b.params.From, b.params.To)}
+	}
+	if b.maxWindow > 0 && w.Range.Duration() > b.maxWindow {
+		return nil, &Error{Err: fmt.Errorf("%w: %s is longer than %s", ErrWindowTooLarge, w.Range.Duration(), b.maxWindow)}
+	}
+
+	if w.Step, err = b.step(q.Get(b.params.Step), w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (b *Binder) parseTime(value, param, def string, loc *time.Location) (time.Time, error) {
+	s := value
+	if s == "" {
+		s = def
+	}
+	if s == "" {
+		return time.Time{}, &Error{Param: param, Err: ErrMissingParam}
+	}
+
+	t, err := b.parser.Parse(s, loc)
+	if err != nil {
+		return time.Time{}, &Error{Param: param, Value: s, Err: fmt.Errorf("%w: %s", ErrInvalidParam, err)}
+	}
+	return t, nil
+}
+
+func (b *Binder) step(value string, w *Window) (*epoch.Interval, error) {
+	options := []epoch.ScheduleOption{epoch.WithScheduleLocation(w.Location), epoch.WithScheduleUnits(b.parser.Units())}
+
+	if value == "" {
+		if !b.autoStep || b.maxPoints <= 0 {
+			return nil, nil
+		}
+		step, err := epoch.AutoStep(w.Range, b.maxPoints, b.allowedSteps, options...)
+		if err != nil {
+			return nil, &Error{Err: err}
+		}
+		return step, nil
+	}
+
+	step, err := b.parser.Units().ParseInterval(value)
+	if err != nil {
+		return nil, &Error{Param: b.params.Step, Value: value, Err: fmt.Errorf("%w: %s", ErrInvalidParam, err)}
+	}
+
+	if b.maxPoints <= 0 {
+		_, err = epoch.NewIntervalSchedule(step, options...)
+	} else {
+		// a single allowed step checks both the alignment and the number of points
+		_, err = epoch.AutoStep(w.Range, b.maxPoints, []*epoch.Interval{step}, options...)
+	}
+	if err != nil {
+		if !errors.Is(err, epoch.ErrTooManyPoints) {
+			err = fmt.Errorf("%w: %s", ErrInvalidParam, err)
+		}
+		return nil, &Error{Param: b.params.Step, Value: value, Err: err}
+	}
+	return step, nil
+}
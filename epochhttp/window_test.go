@@ -0,0 +1,124 @@
+package epochhttp_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/aahainc/epoch"
+	"github.com/aahainc/epoch/epochhttp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Binder", func() {
+	now := time.Date(2024, time.January, 10, 12, 0, 0, 0, time.UTC)
+	parser := epoch.NewTimeParser(epoch.WithIntervalArithmetics(), epoch.WithClock(epoch.NewFakeClock(now)))
+
+	bind := func(query string, options ...epochhttp.Option) (*epochhttp.Window, error) {
+		r := httptest.NewRequest(http.MethodGet, "/series?"+query, nil)
+		return epochhttp.NewBinder(parser, options...).Bind(r)
+	}
+
+	It("binds from, to and step", func() {
+		w, err := bind("from=now,-1h&to=now&step=5m")
+		Expect(err).To(Succeed())
+		Expect(w.Range).To(Equal(epoch.NewRange(now.Add(-time.Hour), now)))
+		Expect(w.Step).To(Equal(epoch.MustParseInterval("5m")))
+		Expect(w.Location).To(Equal(time.UTC))
+	})
+
+	It("uses the timezone", func() {
+		w, err := bind("from=today&tz=Asia/Tokyo")
+		Expect(err).To(Succeed())
+		Expect(w.Location.String()).To(Equal("Asia/Tokyo"))
+		Expect(w.Range.Start.Equal(time.Date(2024, time.January, 9, 15, 0, 0, 0, time.UTC))).To(BeTrue())
+	})
+
+	It("uses defaults", func() {
+		w, err := bind("", epochhttp.WithDefaults("now,-1d", "now"))
+		Expect(err).To(Succeed())
+		Expect(w.Range.Duration()).To(Equal(24 * time.Hour))
+		Expect(w.Step).To(BeNil())
+	})
+
+	It("chooses the step", func() {
+		w, err := bind("from=now,-1d", epochhttp.WithMaxPoints(100), epochhttp.WithAutoStep())
+		Expect(err).To(Succeed())
+		Expect(w.Step.String()).To(Equal("15m"))
+	})
+
+	It("uses custom parameter names", func() {
+		w, err := bind("start=now,-1h&end=now", epochhttp.WithParams(epochhttp.Params{From: "start", To: "end", Step: "interval", TZ: "zone"}))
+		Expect(err).To(Succeed())
+		Expect(w.Range.Duration()).To(Equal(time.Hour))
+	})
+
+	DescribeTable("invalid windows", func(query string, param string, expected error) {
+		_, err := bind(query, epochhttp.WithMaxWindow(7*24*time.Hour), epochhttp.WithMaxPoints(1000))
+		var bindErr *epochhttp.Error
+		Expect(errors.As(err, &bindErr)).To(BeTrue())
+		Expect(bindErr.Param).To(Equal(param))
+		Expect(errors.Is(err, expected)).To(BeTrue(), err.Error())
+	},
+		Entry("missing from", "to=now", "from", epochhttp.ErrMissingParam),
+		Entry("invalid from", "from=yesterday-ish", "from", epochhttp.ErrInvalidParam),
+		Entry("invalid timezone", "from=now,-1h&tz=Mars/Olympus", "tz", epochhttp.ErrInvalidParam),
+		Entry("invalid step", "from=now,-1h&step=5x", "step", epochhttp.ErrInvalidParam),
+		Entry("step can't be aligned", "from=now,-1h&step=1.5mo", "step", epochhttp.ErrInvalidParam),
+		Entry("from after to", "from=now&to=now,-1h", "", epochhttp.ErrInvalidWindow),
+		Entry("window too large", "from=now,-8d", "", epochhttp.ErrWindowTooLarge),
+		Entry("too many points", "from=now,-1d&step=1s", "step", epoch.ErrTooManyPoints),
+	)
+
+	Context("problem details", func() {
+		serve := func(query string, options ...epochhttp.Option) *httptest.ResponseRecorder {
+			h := epochhttp.NewBinder(parser, options...).Handler(func(w http.ResponseWriter, r *http.Request, window *epochhttp.Window) {
+				_, _ = w.Write([]byte(window.Range.String()))
+			})
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/series?"+query, nil))
+			return rec
+		}
+
+		It("passes the window to the handler", func() {
+			rec := serve("from=now,-1h")
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Body.String()).To(Equal("2024-01-10T11:00:00Z/2024-01-10T12:00:00Z"))
+		})
+
+		It("writes problem details", func() {
+			rec := serve("from=now,-1h&step=5x")
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+			Expect(rec.Header().Get("Content-Type")).To(Equal(epochhttp.ContentTypeProblem))
+
+			var p epochhttp.Problem
+			Expect(json.Unmarshal(rec.Body.Bytes(), &p)).To(Succeed())
+			Expect(p.Type).To(Equal("about:blank"))
+			Expect(p.Title).To(Equal("Bad Request"))
+			Expect(p.Status).To(Equal(http.StatusBadRequest))
+			Expect(p.Param).To(Equal("step"))
+			Expect(p.Instance).To(Equal("/series"))
+			Expect(p.Detail).To(ContainSubstring(`step="5x": invalid parameter`))
+		})
+
+		It("uses problem types", func() {
+			rec := serve("from=now,-8d", epochhttp.WithMaxWindow(24*time.Hour), epochhttp.WithProblemTypes("https://example.com/problems/"))
+
+			var p epochhttp.Problem
+			Expect(json.Unmarshal(rec.Body.Bytes(), &p)).To(Succeed())
+			Expect(p.Type).To(Equal("https://example.com/problems/window-too-large"))
+			Expect(p.Title).To(Equal("Time window too large"))
+			Expect(p.Param).To(BeEmpty())
+		})
+
+		It("hides other errors", func() {
+			r := httptest.NewRequest(http.MethodGet, "/series", nil)
+			p := epochhttp.NewBinder(parser).Problem(r, errors.New("database is down"))
+			Expect(p.Status).To(Equal(http.StatusInternalServerError))
+			Expect(p.Detail).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,333 @@
+package epoch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidLocation = fmt.Errorf("invalid location")
+)
+
+// ExpressionOpKind is a kind of operation applied to the anchor of an Expression
+type ExpressionOpKind int
+
+const (
+	// ExpressionOpAddInterval adds an interval to the time, e.g. "-1d"
+	ExpressionOpAddInterval ExpressionOpKind = iota
+	// ExpressionOpTruncate rounds the time down to the start of a unit, e.g. "/d"
+	ExpressionOpTruncate
+)
+
+// ExpressionOp is a single operation of an Expression
+type ExpressionOp struct {
+	Kind ExpressionOpKind
+	// Raw is the operation as it was given in the expression, e.g. "-1d" or "/d"
+	Raw string
+	// Interval is set for ExpressionOpAddInterval
+	Interval *Interval
+	// Unit is set for ExpressionOpTruncate
+	Unit Unit
+}
+
+// Expression is a compiled time expression, e.g. "today,-1d,/w".
+// It consists of an anchor (resolved by one of parsers) and operations applied to it in order.
+//
+// Unlike TimeParser.Parse, that resolves relative inputs once, an Expression can be evaluated
+// many times against a clock. It's immutable and safe for concurrent use.
+type Expression struct {
+	source   string
+	anchor   string
+	parser   Parser
+	units    *UnitRegistry
+	ops      []ExpressionOp
+	relative bool
+	location *time.Location
+	// parserLocation is the location set by WithLocation, trace tells if the evaluation is traced (see WithTrace)
+	parserLocation *time.Location
+	trace          bool
+}
+
+// Compile compiles the given string into an Expression that can be evaluated later (see Expression.Eval)
+func (tp *TimeParser) Compile(s string) (*Expression, error) {
+	e, _, _, err := tp.compile(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile time expression: %w", err)
+	}
+	return e, nil
+}
+
+// anchorParser resolves the anchor of an expression and returns the parser that was chosen
+type anchorParser func(anchor string, locArg ...*time.Location) (time.Time, *ParseDetails, Parser, error)
+
+// compile compiles the given string and returns the anchor time parsed along the way
+func (tp *TimeParser) compile(s string, locArg ...*time.Location) (*Expression, time.Time, *ParseDetails, error) {
+	return tp.compileWith(tp.parseTime, s, locArg...)
+}
+
+// compileWith is the same as compile, but the anchor is resolved by the given function
+func (tp *TimeParser) compileWith(parseAnchor anchorParser, s string, locArg ...*time.Location) (*Expression, time.Time, *ParseDetails, error) {
+	expr, zone, ok, explicit := splitLocationQualifier(s)
+	if !ok {
+		return tp.compileQualified(parseAnchor, s, s, nil, locArg...)
+	}
+
+	location, err := ParseLocation(zone)
+	if err == nil {
+		// the zone qualifier overrides the given location
+		return tp.compileQualified(parseAnchor, s, expr, location, location)
+	}
+	if explicit {
+		return nil, time.Time{}, nil, err
+	}
+
+	// "@" may be a part of the time itself (e.g. "@1700000000" of a custom parser),
+	// the qualifier error is reported only if the whole string can't be parsed either
+	e, t, details, parseErr := tp.compileQualified(parseAnchor, s, s, nil, locArg...)
+	if parseErr != nil {
+		return nil, time.Time{}, nil, err
+	}
+	return e, t, details, nil
+}
+
+// compileQualified compiles the expression without its zone qualifier, location is the qualifier's one (if any)
+func (tp *TimeParser) compileQualified(parseAnchor anchorParser, source, s string, location *time.Location, locArg ...*time.Location) (*Expression, time.Time, *ParseDetails, error) {
+	inputs := strings.Split(s, ",")
+	t, details, parser, err := parseAnchor(inputs[0], locArg...)
+	if err != nil {
+		return nil, time.Time{}, nil, err
+	}
+
+	// comma-separated value are allowed only if interval arithmetics is enabled
+	if len(inputs) > 1 && !tp.withIntervalArithmetics {
+		return nil, time.Time{}, nil, fmt.Errorf("unsupported time format")
+	}
+
+	e := &Expression{
+		source:   source,
+		anchor:   inputs[0],
+		parser:   parser,
+		units:    tp.units,
+		relative: details.IsRelative,
+		location: location,
+
+		parserLocation: tp.location,
+		trace:          tp.trace,
+	}
+
+	for _, raw := range inputs[1:] {
+		op, err := tp.compileOp(raw)
+		if err != nil {
+			return nil, time.Time{}, nil, err
+		}
+		e.ops = append(e.ops, op)
+	}
+
+	return e, t, details, nil
+}
+
+func (tp *TimeParser) compileOp(raw string) (ExpressionOp, error) {
+	if strings.HasPrefix(raw, "/") {
+		unit := tp.units.Get(raw[1:])
+		if _, ok := tp.units.Truncate(time.Time{}, unit); !ok {
+			return ExpressionOp{}, fmt.Errorf("failed to parse rounding [%s]: %w", raw, ErrInvalidUnit)
+		}
+		return ExpressionOp{Kind: ExpressionOpTruncate, Raw: raw, Unit: unit}, nil
+	}
+
+	interval, err := tp.units.ParseInterval(raw)
+	if err != nil {
+		return ExpressionOp{}, fmt.Errorf("failed to parse interval [%s]: %w", raw, err)
+	}
+	return ExpressionOp{Kind: ExpressionOpAddInterval, Raw: raw, Interval: interval}, nil
+}
+
+// Eval evaluates the expression using the current time of the given clock in the given location.
+// If nil clock is given, the own clock of the parser is used.
+// If nil location is given, the parser's default behavior is used (same as Parse without locArg).
+// The zone qualifier of the expression (e.g. "today@Europe/Berlin") takes precedence over the given location.
+func (e *Expression) Eval(clock Clock, loc *time.Location) (time.Time, error) {
+	t, _, err := e.EvalExt(clock, loc)
+	return t, err
+}
+
+// EvalExt is the same as Eval, but also returns details of parsing
+func (e *Expression) EvalExt(clock Clock, loc *time.Location) (time.Time, *ParseDetails, error) {
+	if e.location != nil {
+		loc = e.location
+	}
+
+	var locArg []*time.Location
+	if loc != nil {
+		locArg = append(locArg, loc)
+	}
+
+	var t time.Time
+	var details *ParseDetails
+	var err error
+	if rp, ok := e.parser.(RelativeParser); ok && clock != nil {
+		t, details, err = rp.ParseWithClock(e.anchor, clock, locArg...)
+	} else {
+		t, details, err = e.parser.Parse(e.anchor, locArg...)
+	}
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("failed to parse time: %w", err)
+	}
+	details = detailsOrDefault(e.parser, details)
+
+	t, details = e.applyTraced(t, details, locArg)
+	return t, details, nil
+}
+
+// applyTraced applies all operations of the expression to the anchor time,
+// recording a trace in the details if the expression is traced
+func (e *Expression) applyTraced(t time.Time, details *ParseDetails, locArg []*time.Location) (time.Time, *ParseDetails) {
+	if !e.trace {
+		return e.apply(t, details)
+	}
+
+	trace := e.newTrace(t, details, locArg)
+	t, details = e.applyOps(t, details, trace)
+	trace.Result = t
+	details.Trace = trace
+	return t, details
+}
+
+// apply applies all operations of the expression to the anchor time
+func (e *Expression) apply(t time.Time, details *ParseDetails) (time.Time, *ParseDetails) {
+	return e.applyOps(t, details, nil)
+}
+
+// applyOps applies all operations of the expression, recording them into the trace if it's not nil
+func (e *Expression) applyOps(t time.Time, details *ParseDetails, trace *Trace) (time.Time, *ParseDetails) {
+	if len(e.ops) > 0 {
+		arithmetics := &Arithmetics{}
+		for _, op := range e.ops {
+			before := t
+			switch op.Kind {
+			case ExpressionOpAddInterval:
+				t = e.units.AddInterval(t, op.Interval)
+				arithmetics.Intervals = append(arithmetics.Intervals, *op.Interval)
+				arithmetics.RawIntervals = append(arithmetics.RawIntervals, op.Raw)
+			case ExpressionOpTruncate:
+				t, _ = e.units.Truncate(t, op.Unit)
+				arithmetics.Rounding = append(arithmetics.Rounding, op.Unit.Short)
+			}
+			if trace != nil {
+				trace.add(op, before, t)
+			}
+		}
+		details.Arithmetics = arithmetics
+	}
+
+	details.Location = locationName(t)
+	return t, details
+}
+
+// IsRelative returns true if the result of the expression depends on the current time
+func (e *Expression) IsRelative() bool {
+	return e.relative
+}
+
+// Anchor returns the part of the expression resolved by a parser, e.g. "today" for "today,-1d"
+func (e *Expression) Anchor() string {
+	return e.anchor
+}
+
+// Location returns the location given by the zone qualifier of the expression (nil if there is none)
+func (e *Expression) Location() *time.Location {
+	return e.location
+}
+
+// ParserName returns the name of the parser that resolves the anchor
+func (e *Expression) ParserName() string {
+	return e.parser.Name()
+}
+
+// Ops returns the operations applied to the anchor in order
+func (e *Expression) Ops() []ExpressionOp {
+	return append([]ExpressionOp(nil), e.ops...)
+}
+
+// splitLocationQualifier splits a zone qualifier off the expression.
+// Both "today,-1d@America/New_York" and "today,-1d TZ=America/New_York" forms are supported.
+// The qualifier is explicit if it follows whitespace ("today @UTC" or " TZ="), otherwise "@" may be a part of the time.
+func splitLocationQualifier(s string) (expr string, zone string, ok bool, explicit bool) {
+	if i := strings.LastIndex(s, " TZ="); i >= 0 {
+		return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+len(" TZ="):]), true, true
+	}
+	if i := strings.LastIndex(s, "@"); i >= 0 {
+		expr := strings.TrimRight(s[:i], " ")
+		return expr, s[i+1:], true, expr != s[:i]
+	}
+	return s, "", false, false
+}
+
+// ParseLocation resolves a zone given either as an IANA name (e.g. "Europe/Berlin")
+// or as a fixed offset (e.g. "+05:30", "-0800", "+05", "Z")
+func ParseLocation(zone string) (*time.Location, error) {
+	if zone == "" {
+		return nil, fmt.Errorf("%w: empty location", ErrInvalidLocation)
+	}
+	if zone == "Z" {
+		return time.UTC, nil
+	}
+
+	if zone[0] == '+' || zone[0] == '-' {
+		offset, ok := parseZoneOffset(zone)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidLocation, zone)
+		}
+		return time.FixedZone(zone, offset), nil
+	}
+
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidLocation, err)
+	}
+	return loc, nil
+}
+
+// parseZoneOffset parses offsets like "+05:30", "-0800" or "+05" into seconds east of UTC
+func parseZoneOffset(s string) (int, bool) {
+	sign := 1
+	if s[0] == '-' {
+		sign = -1
+	}
+
+	digits := strings.Replace(s[1:], ":", "", 1)
+	if len(digits) != 2 && len(digits) != 4 {
+		return 0, false
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+	}
+
+	hours, _ := strconv.Atoi(digits[:2])
+	minutes := 0
+	if len(digits) == 4 {
+		minutes, _ = strconv.Atoi(digits[2:])
+	}
+	if hours > 14 || minutes > 59 {
+		return 0, false
+	}
+
+	return sign * (hours*3600 + minutes*60), true
+}
+
+// locationName returns the name of t's location, or its offset (e.g. "-07:00") for unnamed fixed zones
+func locationName(t time.Time) string {
+	if name := t.Location().String(); name != "" {
+		return name
+	}
+	return t.Format("-07:00")
+}
+
+// String returns the source of the expression
+func (e *Expression) String() string {
+	return e.source
+}
@@ -0,0 +1,219 @@
+package epoch_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aahainc/epoch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Expression", func() {
+	var p *epoch.TimeParser
+	BeforeEach(func() {
+		epoch.BaseParserFormat = time.RFC3339
+		p = epoch.NewTimeParser(epoch.WithIntervalArithmetics())
+	})
+
+	It("re-evaluates relative expressions against a clock", func() {
+		e, err := p.Compile("today,-1d")
+		Expect(err).Should(Succeed())
+		Expect(e.IsRelative()).To(BeTrue())
+		Expect(e.Anchor()).To(Equal("today"))
+		Expect(e.ParserName()).To(Equal(epoch.ParserNameAliases))
+		Expect(e.String()).To(Equal("today,-1d"))
+
+		t, err := e.Eval(epoch.NewStaticClock(time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)), time.UTC)
+		Expect(err).Should(Succeed())
+		Expect(t).To(Equal(time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC)))
+
+		t, err = e.Eval(epoch.NewStaticClock(time.Date(2023, time.June, 15, 1, 0, 0, 0, time.UTC)), time.UTC)
+		Expect(err).Should(Succeed())
+		Expect(t).To(Equal(time.Date(2023, time.June, 14, 0, 0, 0, 0, time.UTC)))
+	})
+
+	It("evaluates in the given location", func() {
+		loc, _ := time.LoadLocation("Asia/Tokyo")
+		e, err := p.Compile("today")
+		Expect(err).Should(Succeed())
+
+		t, err := e.Eval(epoch.NewStaticClock(time.Date(2006, time.January, 2, 20, 0, 0, 0, time.UTC)), loc)
+		Expect(err).Should(Succeed())
+		Expect(t).To(Equal(time.Date(2006, time.January, 3, 0, 0, 0, 0, loc)))
+	})
+
+	It("evaluates absolute expressions", func() {
+		e, err := p.Compile("2018-03-01T12:30:00Z,+1h")
+		Expect(err).Should(Succeed())
+		Expect(e.IsRelative()).To(BeFalse())
+
+		t, details, err := e.EvalExt(nil, nil)
+		Expect(err).Should(Succeed())
+		Expect(t).To(BeTemporally("==", time.Date(2018, time.March, 1, 13, 30, 0, 0, time.UTC)))
+		Expect(details.ParserName).To(Equal(epoch.ParserNameBase))
+		Expect(details.Arithmetics.RawIntervals).To(Equal([]string{"+1h"}))
+	})
+
+	It("exposes operations", func() {
+		e, err := p.Compile("today,-1d,/w")
+		Expect(err).Should(Succeed())
+
+		ops := e.Ops()
+		Expect(ops).To(HaveLen(2))
+		Expect(ops[0].Kind).To(Equal(epoch.ExpressionOpAddInterval))
+		Expect(ops[0].Interval.String()).To(Equal("-1d"))
+		Expect(ops[1].Kind).To(Equal(epoch.ExpressionOpTruncate))
+		Expect(ops[1].Unit).To(Equal(epoch.UnitWeek))
+	})
+
+	DescribeTable("rounding", func(input string, expected time.Time) {
+		e, err := p.Compile(input)
+		Expect(err).Should(Succeed())
+
+		// 2006-01-04 is Wednesday
+		t, details, err := e.EvalExt(epoch.NewStaticClock(time.Date(2006, time.May, 3, 15, 4, 5, 0, time.UTC)), time.UTC)
+		Expect(err).Should(Succeed())
+		Expect(t).To(Equal(expected))
+		Expect(details.Arithmetics.Rounding).NotTo(BeEmpty())
+	},
+		Entry("to the hour", "tomorrow,+90m,/h", time.Date(2006, time.May, 4, 1, 0, 0, 0, time.UTC)),
+		Entry("to the week", "today,/w", time.Date(2006, time.May, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("to the month", "today,/mo", time.Date(2006, time.May, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("to the previous month", "today,/mo,-1mo", time.Date(2006, time.April, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("to the quarter", "today,/q", time.Date(2006, time.April, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("to the year", "today,/y", time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC)),
+	)
+
+	DescribeTable("invalid expressions", func(input string, expectedErr error) {
+		_, err := p.Compile(input)
+		Expect(err).To(HaveOccurred())
+		if expectedErr != nil {
+			Expect(errors.Is(err, expectedErr)).To(BeTrue())
+		}
+	},
+		Entry("unknown anchor", "someday,-1d", nil),
+		Entry("invalid interval", "today,-1x", epoch.ErrInvalidUnit),
+		Entry("invalid rounding", "today,/x", epoch.ErrInvalidUnit),
+	)
+
+	It("requires interval arithmetics for operations", func() {
+		_, err := epoch.NewTimeParser().Compile("today,-1d")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("is safe for concurrent use", func() {
+		e, err := p.Compile("today,-1d,/w")
+		Expect(err).Should(Succeed())
+
+		var wg sync.WaitGroup
+		for i := 0; i < 16; i++ {
+			wg.Add(1)
+			go func(day int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				now := time.Date(2023, time.June, day+1, 12, 0, 0, 0, time.UTC)
+				t, err := e.Eval(epoch.NewStaticClock(now), time.UTC)
+				Expect(err).Should(Succeed())
+				Expect(t).To(Equal(epoch.TruncateToWeek(now.AddDate(0, 0, -1))))
+			}(i)
+		}
+		wg.Wait()
+	})
+})
+
+var _ = Describe("Zone qualifiers", func() {
+	fixedNow := time.Date(2006, time.January, 2, 22, 0, 0, 0, time.UTC)
+
+	var p *epoch.TimeParser
+	BeforeEach(func() {
+		epoch.BaseParserFormat = time.RFC3339
+		p = epoch.NewTimeParser(
+			epoch.WithParsers(epoch.NewBaseParser(), epoch.NewUnixSecondsParser(), epoch.NewAliasesParser().SetClock(epoch.NewStaticClock(fixedNow))),
+			epoch.WithIntervalArithmetics(),
+		)
+	})
+
+	newYork, _ := time.LoadLocation("America/New_York")
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	DescribeTable("resolves the expression in the qualified zone", func(input string, expected time.Time, expectedLocation string) {
+		// locArg is overridden by the qualifier
+		t, details, err := p.ParseExt(input, time.UTC)
+		Expect(err).Should(Succeed())
+		Expect(t).To(BeTemporally("==", expected))
+		Expect(details.Location).To(Equal(expectedLocation))
+	},
+		Entry("IANA name with @", "today@America/New_York", time.Date(2006, time.January, 2, 0, 0, 0, 0, newYork), "America/New_York"),
+		Entry("IANA name with TZ=", "yesterday TZ=Europe/Berlin", time.Date(2006, time.January, 1, 0, 0, 0, 0, berlin), "Europe/Berlin"),
+		Entry("with arithmetics", "today,-1d@Europe/Berlin", time.Date(2006, time.January, 1, 0, 0, 0, 0, berlin), "Europe/Berlin"),
+		Entry("fixed offset", "today@+05:30", time.Date(2006, time.January, 3, 0, 0, 0, 0, time.FixedZone("", 5*3600+1800)), "+05:30"),
+		Entry("compact fixed offset", "today@-0800", time.Date(2006, time.January, 2, 0, 0, 0, 0, time.FixedZone("", -8*3600)), "-0800"),
+		Entry("unix timestamp", "1136239445@Asia/Tokyo", time.Date(2006, time.January, 2, 22, 4, 5, 0, time.UTC), "Asia/Tokyo"),
+		Entry("after whitespace", "today @Europe/Berlin", time.Date(2006, time.January, 2, 0, 0, 0, 0, berlin), "Europe/Berlin"),
+	)
+
+	It("passes @ that isn't a zone qualifier to parsers", func() {
+		p.Register(atUnixParser{})
+
+		t, err := p.Parse("@1700000000")
+		Expect(err).Should(Succeed())
+		Expect(t).To(Equal(time.Unix(1700000000, 0).UTC()))
+
+		t, err = p.Parse("@1700000000,+1h")
+		Expect(err).Should(Succeed())
+		Expect(t).To(Equal(time.Unix(1700000000+3600, 0).UTC()))
+
+		t, err = p.Parse("@1700000000 @Asia/Tokyo")
+		Expect(err).Should(Succeed())
+		Expect(t.Location().String()).To(Equal("Asia/Tokyo"))
+	})
+
+	It("reports the location given by locArg", func() {
+		_, details, err := p.ParseExt("today", newYork)
+		Expect(err).Should(Succeed())
+		Expect(details.Location).To(Equal("America/New_York"))
+	})
+
+	It("keeps the qualifier in compiled expressions", func() {
+		e, err := p.Compile("today@Europe/Berlin")
+		Expect(err).Should(Succeed())
+		Expect(e.Location()).To(Equal(berlin))
+
+		t, err := e.Eval(epoch.NewStaticClock(fixedNow), time.UTC)
+		Expect(err).Should(Succeed())
+		Expect(t).To(Equal(time.Date(2006, time.January, 2, 0, 0, 0, 0, berlin)))
+	})
+
+	DescribeTable("rejects invalid zones", func(input string) {
+		_, err := p.Parse(input)
+		Expect(errors.Is(err, epoch.ErrInvalidLocation)).To(BeTrue())
+	},
+		Entry("unknown name", "today@Mars/Olympus_Mons"),
+		Entry("empty", "today@"),
+		Entry("bad offset", "today@+5:3"),
+		Entry("too large offset", "today@+25:00"),
+		Entry("unknown name after whitespace", "today @Mars/Olympus_Mons"),
+	)
+})
+
+// atUnixParser is a user-defined parser of unix seconds prefixed with "@" (like in GNU date)
+type atUnixParser struct{}
+
+func (atUnixParser) Match(s string) bool { return strings.HasPrefix(s, "@") }
+func (atUnixParser) Name() string        { return "at-unix" }
+func (atUnixParser) Parse(s string, locArg ...*time.Location) (time.Time, *epoch.ParseDetails, error) {
+	sec, err := strconv.ParseInt(s[1:], 10, 64)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	t := time.Unix(sec, 0).UTC()
+	if len(locArg) > 0 && locArg[0] != nil {
+		t = t.In(locArg[0])
+	}
+	return t, &epoch.ParseDetails{ParserName: "at-unix"}, nil
+}
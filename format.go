@@ -0,0 +1,144 @@
+package epoch
+
+import (
+	"strconv"
+	"time"
+)
+
+// maxFormatOffset limits the value of an offset in relative expressions produced by Format,
+// e.g. "now,-15m" is fine, but "now,-94608017s" is rendered as an absolute time instead
+const maxFormatOffset = 1000
+
+// formatOffsetUnits are units used for offsets in relative expressions, from the largest one
+var formatOffsetUnits = []Unit{UnitWeek, UnitDay, UnitHour, UnitMinute, UnitSecond, UnitMillisecond, UnitMicrosecond, UnitNanosecond}
+
+// Format renders t back into an expression the parser accepts, e.g. "today", "yesterday,+6h", "now,-15m"
+// or an absolute time in the format of BaseParser (RFC3339 by default).
+//
+// Relative expressions are built from the alias closest to t (evaluated against the clock in t's location),
+// either as is or with a single offset of less than 1000 units (offsets require interval arithmetics).
+// The shortest of the relative and the absolute forms is returned.
+//
+// Every returned expression is verified: parsing it with the same clock in t's location yields the same instant.
+// If nil clock is given, the clock of the parser (see WithClock) or the DefaultClock is used.
+func (tp *TimeParser) Format(t time.Time, clock Clock) string {
+	if clock == nil {
+		clock = tp.clock
+	}
+	if clock == nil {
+		clock = NewDefaultClock()
+	}
+
+	absolute := tp.formatAbsolute(t, clock)
+	relative, ok := tp.formatRelative(t, clock)
+	if ok && (absolute == "" || len(relative) <= len(absolute)) {
+		return relative
+	}
+	if absolute != "" {
+		return absolute
+	}
+
+	// there is no parser able to read t back: the best we can do
+	return t.Format(time.RFC3339Nano)
+}
+
+// FormatTime renders t into an expression using the DefaultTimeParser (see TimeParser.Format)
+func FormatTime(t time.Time, clock Clock) string {
+	return DefaultTimeParser.Format(t, clock)
+}
+
+func (tp *TimeParser) formatAbsolute(t time.Time, clock Clock) string {
+	format := BaseParserFormat
+	if format == time.RFC3339 {
+		// RFC3339 parser accepts fractional seconds as well
+		format = time.RFC3339Nano
+	}
+
+	s := t.Format(format)
+	if !tp.formatRoundTrips(s, t, clock) {
+		return ""
+	}
+	return s
+}
+
+func (tp *TimeParser) formatRelative(t time.Time, clock Clock) (string, bool) {
+	now := clock.Now().In(t.Location())
+
+	var best string
+	var bestOffset time.Duration
+	found := false
+
+	for _, parser := range tp.activeParsers() {
+		dictionary, ok := parser.(interface{ GetDictionary() []Alias })
+		if !ok {
+			continue
+		}
+
+		for _, alias := range dictionary.GetDictionary() {
+			offset := t.Sub(alias.Callback(now))
+			candidate, ok := tp.formatAliasOffset(alias.Slug, offset)
+			if !ok {
+				continue
+			}
+
+			if found && (absDuration(offset) > absDuration(bestOffset) ||
+				(absDuration(offset) == absDuration(bestOffset) && len(candidate) >= len(best))) {
+				continue
+			}
+			if !tp.formatRoundTrips(candidate, t, clock) {
+				continue
+			}
+
+			best, bestOffset, found = candidate, offset, true
+		}
+	}
+
+	return best, found
+}
+
+// formatAliasOffset renders an alias with the given offset, e.g. "yesterday,+6h"
+func (tp *TimeParser) formatAliasOffset(slug string, offset time.Duration) (string, bool) {
+	if offset == 0 {
+		return slug, true
+	}
+	if !tp.withIntervalArithmetics {
+		return "", false
+	}
+
+	for _, unit := range formatOffsetUnits {
+		d := (&Interval{Value: 1, Unit: unit}).Duration()
+		if offset%d != 0 {
+			continue
+		}
+
+		value := int64(offset / d)
+		if value <= -maxFormatOffset || value >= maxFormatOffset {
+			return "", false
+		}
+
+		sign := "+"
+		if value < 0 {
+			sign = ""
+		}
+		return slug + "," + sign + strconv.FormatInt(value, 10) + unit.Short, true
+	}
+
+	return "", false
+}
+
+func (tp *TimeParser) formatRoundTrips(s string, t time.Time, clock Clock) bool {
+	e, err := tp.Compile(s)
+	if err != nil {
+		return false
+	}
+
+	parsed, err := e.Eval(clock, t.Location())
+	return err == nil && parsed.Equal(t)
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,66 @@
+package epoch_test
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/aahainc/epoch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Format", func() {
+	fixedNow := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+	clock := epoch.NewStaticClock(fixedNow)
+
+	var p *epoch.TimeParser
+	BeforeEach(func() {
+		epoch.BaseParserFormat = time.RFC3339
+		p = epoch.NewTimeParser(epoch.WithIntervalArithmetics())
+	})
+
+	DescribeTable("renders the canonical expression", func(t time.Time, expected string) {
+		Expect(p.Format(t, clock)).To(Equal(expected))
+	},
+		Entry("now", fixedNow, "now"),
+		Entry("today", time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC), "today"),
+		Entry("yesterday", time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC), "yesterday"),
+		Entry("yesterday with offset", time.Date(2006, time.January, 1, 6, 0, 0, 0, time.UTC), "yesterday,+6h"),
+		Entry("now with offset", fixedNow.Add(-15*time.Minute), "now,-15m"),
+		Entry("today with offset", time.Date(2006, time.January, 2, 9, 30, 0, 0, time.UTC), "today,+570m"),
+		Entry("far in the past", time.Date(2001, time.March, 4, 5, 6, 7, 0, time.UTC), "2001-03-04T05:06:07Z"),
+		Entry("with nanoseconds", time.Date(2001, time.March, 4, 5, 6, 7, 8, time.UTC), "2001-03-04T05:06:07.000000008Z"),
+	)
+
+	It("renders aliases only without interval arithmetics", func() {
+		p = epoch.NewTimeParser()
+		Expect(p.Format(time.Date(2006, time.January, 3, 0, 0, 0, 0, time.UTC), clock)).To(Equal("tomorrow"))
+		Expect(p.Format(fixedNow.Add(-15*time.Minute), clock)).To(Equal("2006-01-02T14:49:05Z"))
+	})
+
+	It("renders relative to the location of the time", func() {
+		loc, _ := time.LoadLocation("Asia/Tokyo")
+		Expect(p.Format(time.Date(2006, time.January, 3, 0, 0, 0, 0, loc), clock)).To(Equal("today"))
+	})
+
+	It("round-trips", func() {
+		rnd := rand.New(rand.NewSource(42))
+		loc, _ := time.LoadLocation("America/Los_Angeles")
+		steps := []time.Duration{time.Nanosecond, time.Millisecond, time.Second, time.Minute, time.Hour, 24 * time.Hour}
+
+		for i := 0; i < 500; i++ {
+			step := steps[rnd.Intn(len(steps))]
+			t := fixedNow.Add(time.Duration(rnd.Int63n(4000)-2000) * step)
+			if i%2 == 0 {
+				t = t.In(loc)
+			}
+
+			s := p.Format(t, clock)
+			e, err := p.Compile(s)
+			Expect(err).Should(Succeed(), s)
+			parsed, err := e.Eval(clock, t.Location())
+			Expect(err).Should(Succeed(), s)
+			Expect(parsed).To(BeTemporally("==", t), s)
+		}
+	})
+})
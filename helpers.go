@@ -6,13 +6,69 @@ import (
 
 // TruncateToHour truncates the given time to the hour by rounding down
 func TruncateToHour(t time.Time) time.Time {
-	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	return notAfter(time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location()), t)
 }
 
 func TruncateToDay(t time.Time) time.Time {
 	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
 }
 
+// TruncateToWeek truncates the given time to the start of its ISO week (Monday)
+func TruncateToWeek(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day()-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+}
+
+// TruncateToMonth truncates the given time to the first day of its month
+func TruncateToMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// TruncateToQuarter truncates the given time to the first day of its quarter
+func TruncateToQuarter(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month()-(t.Month()-1)%3, 1, 0, 0, 0, 0, t.Location())
+}
+
+// TruncateToYear truncates the given time to the first day of its year
+func TruncateToYear(t time.Time) time.Time {
+	return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+}
+
+// truncateClock truncates the wall clock of the given time to a multiple of d since the midnight
+func truncateClock(t time.Time, d time.Duration) time.Time {
+	h, m, s := t.Clock()
+	sinceMidnight := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second +
+		time.Duration(t.Nanosecond())
+	sinceMidnight -= sinceMidnight % d
+	return notAfter(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, int(sinceMidnight), t.Location()), t)
+}
+
+// notAfter fixes the truncated time if time.Date picked the later occurrence of an ambiguous wall clock
+// (when DST ends), so the result of truncation is never after the original time
+func notAfter(truncated, t time.Time) time.Time {
+	if !truncated.After(t) {
+		return truncated
+	}
+	_, offsetTruncated := truncated.Zone()
+	_, offset := t.Zone()
+	return truncated.Add(time.Duration(offsetTruncated-offset) * time.Second)
+}
+
+// TruncateToUnit truncates the given time to the start of the given built-in unit, e.g. "/d" -> start of the day.
+// Weeks start on Monday, business days use DefaultHolidayCalendar.
+// The time is returned as is if the unit is unknown; use UnitRegistry.Truncate for user-defined units.
+func TruncateToUnit(t time.Time, u Unit) time.Time {
+	truncated, _ := defaultUnitRegistry.Truncate(t, u)
+	return truncated
+}
+
+// TruncateToInterval rounds t down to the closest boundary of the given interval of built-in units
+// aligned on the wall clock, e.g. 15m -> :00, :15, :30, :45 (see UnitRegistry.TruncateToInterval).
+// The time is returned as is if the interval can't be aligned.
+func TruncateToInterval(t time.Time, i *Interval) time.Time {
+	truncated, _ := defaultUnitRegistry.TruncateToInterval(t, i)
+	return truncated
+}
+
 // RoundUpToHour rounds the given time up to the nearest hour (rounding right)
 func RoundUpToHour(t time.Time) time.Time {
 	return TruncateToHour(t).Add(1 * time.Hour)
@@ -34,11 +90,9 @@ func EffectiveHoursInDay(t time.Time) int {
 // TimeAddInterval adds the given interval to the given time and returns the resulting time.
 // If the interval is a safe duration (can be converted to a precise time.Duration),
 // it will use the t.Add(i.Duration) method.
-// Otherwise, for intervals based on months and years, it will use t.AddDate(i.ExtractDateParts())
+// Otherwise, for intervals based on months, quarters and years, it will use t.AddDate(i.ExtractDateParts())
+//
+// Only built-in units are known here, use UnitRegistry.AddInterval for user-defined units.
 func TimeAddInterval(t time.Time, i *Interval) time.Time {
-	if i.IsSafeDuration() {
-		return t.Add(i.Duration())
-	}
-
-	return t.AddDate(i.ExtractDateParts())
+	return defaultUnitRegistry.AddInterval(t, i)
 }
@@ -35,8 +35,31 @@ var _ = Describe("Helpers", func() {
 				result := epoch.TruncateToHour(a)
 				Expect(result.String()).To(Equal("2019-10-12 05:00:00 +0000 UTC"))
 			})
+
+			It("should truncate to hour (edge case: repeated hour when DST ends)", func() {
+				loc, _ := time.LoadLocation("Europe/Berlin")
+				a := time.Date(2024, 10, 27, 0, 30, 0, 0, time.UTC).In(loc)
+				result := epoch.TruncateToHour(a)
+				Expect(result.String()).To(Equal("2024-10-27 02:00:00 +0200 CEST"))
+			})
 		})
 
+		DescribeTable("Truncate to unit", func(unit epoch.Unit, expected string) {
+			// 2019-10-12 is Saturday
+			a := time.Date(2019, 10, 12, 5, 32, 41, 123456789, time.UTC)
+			Expect(epoch.TruncateToUnit(a, unit).String()).To(Equal(expected))
+		},
+			Entry("millisecond", epoch.UnitMillisecond, "2019-10-12 05:32:41.123 +0000 UTC"),
+			Entry("second", epoch.UnitSecond, "2019-10-12 05:32:41 +0000 UTC"),
+			Entry("minute", epoch.UnitMinute, "2019-10-12 05:32:00 +0000 UTC"),
+			Entry("hour", epoch.UnitHour, "2019-10-12 05:00:00 +0000 UTC"),
+			Entry("day", epoch.UnitDay, "2019-10-12 00:00:00 +0000 UTC"),
+			Entry("week", epoch.UnitWeek, "2019-10-07 00:00:00 +0000 UTC"),
+			Entry("business day", epoch.UnitBusinessDay, "2019-10-11 00:00:00 +0000 UTC"),
+			Entry("month", epoch.UnitMonth, "2019-10-01 00:00:00 +0000 UTC"),
+			Entry("quarter", epoch.UnitQuarter, "2019-10-01 00:00:00 +0000 UTC"),
+			Entry("year", epoch.UnitYear, "2019-01-01 00:00:00 +0000 UTC"),
+		)
 	})
 
 	Context("EffectiveHoursInDay", func() {
@@ -87,6 +110,13 @@ var _ = Describe("Helpers", func() {
 				Expect(result.String()).To(Equal("2019-10-12 04:32:00 +0000 UTC"))
 			})
 
+			It("should add sub-second interval", func() {
+				t := time.Date(2019, 10, 12, 5, 32, 0, 0, time.UTC)
+				i := epoch.MustParseInterval("250ms")
+				result := epoch.TimeAddInterval(t, i)
+				Expect(result.String()).To(Equal("2019-10-12 05:32:00.25 +0000 UTC"))
+			})
+
 			It("should add interval with safe duration", func() {
 				t := time.Date(2019, 10, 12, 5, 32, 0, 0, time.UTC)
 				i := epoch.MustParseInterval("2h")
@@ -135,6 +165,13 @@ var _ = Describe("Helpers", func() {
 				Expect(result.String()).To(Equal("2020-02-15 00:00:00 +0000 UTC"))
 			})
 
+			It("should add quarters", func() {
+				t := time.Date(2019, 10, 12, 5, 32, 0, 0, time.UTC)
+				i := epoch.MustParseInterval("1q")
+				result := epoch.TimeAddInterval(t, i)
+				Expect(result.String()).To(Equal("2020-01-12 05:32:00 +0000 UTC"))
+			})
+
 			It("should handle adding a month to a date at the end of the month (next month has fewer days)", func() {
 				t := time.Date(2022, time.January, 31, 0, 0, 0, 0, time.UTC)
 				i := epoch.MustParseInterval("1mo")
@@ -0,0 +1,195 @@
+package epoch
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCalendar = fmt.Errorf("invalid calendar")
+)
+
+// icsProperty is a single (unfolded) content line of an iCalendar document, e.g. `DTSTART;VALUE=DATE:20240101`
+type icsProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// parseICSProperties splits iCalendar data into unfolded content lines (RFC 5545, section 3.1)
+func parseICSProperties(data []byte) ([]icsProperty, error) {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		// folded line: continuation of the previous one
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCalendar, err)
+	}
+
+	properties := make([]icsProperty, 0, len(lines))
+	for _, line := range lines {
+		p, err := parseICSProperty(line)
+		if err != nil {
+			return nil, err
+		}
+		properties = append(properties, p)
+	}
+
+	return properties, nil
+}
+
+func parseICSProperty(line string) (icsProperty, error) {
+	// the value starts after the first colon that is not inside of a quoted param value
+	inQuotes := false
+	sep := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+		if r == ':' && !inQuotes {
+			sep = i
+			break
+		}
+	}
+	if sep < 0 {
+		return icsProperty{}, fmt.Errorf("%w: malformed line %q", ErrInvalidCalendar, line)
+	}
+
+	head := strings.Split(line[:sep], ";")
+	p := icsProperty{
+		Name:   strings.ToUpper(head[0]),
+		Params: make(map[string]string, len(head)-1),
+		Value:  line[sep+1:],
+	}
+	for _, param := range head[1:] {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 {
+			return icsProperty{}, fmt.Errorf("%w: malformed parameter %q", ErrInvalidCalendar, param)
+		}
+		p.Params[strings.ToUpper(kv[0])] = strings.Trim(kv[1], `"`)
+	}
+
+	return p, nil
+}
+
+// parseICSDateTime parses DATE and DATE-TIME values (RFC 5545, sections 3.3.4 and 3.3.5).
+// Floating times (without "Z" suffix and TZID) are returned in the given default location.
+func parseICSDateTime(value string, params map[string]string, defaultLoc *time.Location) (t time.Time, isDate bool, err error) {
+	loc := defaultLoc
+	if loc == nil {
+		loc = time.UTC
+	}
+	if tzid, ok := params["TZID"]; ok {
+		loc, err = time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%w: unknown TZID %q", ErrInvalidCalendar, tzid)
+		}
+	}
+
+	switch {
+	case params["VALUE"] == "DATE" || len(value) == len("20060102"):
+		t, err = time.ParseInLocation("20060102", value, loc)
+		isDate = true
+	case strings.HasSuffix(value, "Z"):
+		t, err = time.Parse("20060102T150405Z", value)
+	default:
+		t, err = time.ParseInLocation("20060102T150405", value, loc)
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%w: invalid date %q", ErrInvalidCalendar, value)
+	}
+
+	return t, isDate, nil
+}
+
+// unescapeICSText unescapes TEXT values (RFC 5545, section 3.3.11)
+func unescapeICSText(s string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
+
+// ParseICSHolidays returns all days covered by events (VEVENT) of the given iCalendar data.
+// Events spanning several days (all-day events with DTEND) produce a holiday for each day.
+func ParseICSHolidays(data []byte) ([]Holiday, error) {
+	properties, err := parseICSProperties(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var holidays []Holiday
+	var inEvent bool
+	var start, end *icsProperty
+	var name string
+
+	for i := range properties {
+		p := properties[i]
+		switch {
+		case p.Name == "BEGIN" && strings.EqualFold(p.Value, "VEVENT"):
+			inEvent, start, end, name = true, nil, nil, ""
+		case p.Name == "END" && strings.EqualFold(p.Value, "VEVENT"):
+			if !inEvent || start == nil {
+				return nil, fmt.Errorf("%w: event without DTSTART", ErrInvalidCalendar)
+			}
+			inEvent = false
+
+			eventHolidays, err := icsEventHolidays(start, end, name)
+			if err != nil {
+				return nil, err
+			}
+			holidays = append(holidays, eventHolidays...)
+		case !inEvent:
+			continue
+		case p.Name == "DTSTART":
+			start = &properties[i]
+		case p.Name == "DTEND":
+			end = &properties[i]
+		case p.Name == "SUMMARY":
+			name = unescapeICSText(p.Value)
+		}
+	}
+
+	if inEvent {
+		return nil, fmt.Errorf("%w: unterminated event", ErrInvalidCalendar)
+	}
+
+	return holidays, nil
+}
+
+func icsEventHolidays(start, end *icsProperty, name string) ([]Holiday, error) {
+	from, isDate, err := parseICSDateTime(start.Value, start.Params, time.UTC)
+	if err != nil {
+		return nil, err
+	}
+	from = TruncateToDay(from)
+
+	// DTEND is exclusive for all-day events
+	until := from.AddDate(0, 0, 1)
+	if end != nil && isDate {
+		until, _, err = parseICSDateTime(end.Value, end.Params, time.UTC)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var holidays []Holiday
+	for d := from; d.Before(until); d = d.AddDate(0, 0, 1) {
+		holidays = append(holidays, Holiday{Date: d, Name: name})
+	}
+	if len(holidays) == 0 {
+		holidays = append(holidays, Holiday{Date: from, Name: name})
+	}
+
+	return holidays, nil
+}
@@ -16,20 +16,16 @@ type Interval struct {
 	Unit  Unit
 }
 
+// ParseInterval parses an interval in the format of `value+unit` (e.g. 5m) using built-in units.
+// Use UnitRegistry.ParseInterval to parse user-defined units.
+//
+// It doesn't allocate on success as long as the result doesn't escape the caller.
 func ParseInterval(interval string) (*Interval, error) {
-	var value float64
-	var unitShort string
-	n, err := fmt.Sscanf(interval, "%f%s", &value, &unitShort)
-	if n != 2 || err != nil {
-		return nil, fmt.Errorf("%w: %s", ErrInvalidFormat, err)
-	}
-
-	unit := AvailableUnits.Get(unitShort)
-	if unit.IsNil() {
-		return nil, ErrInvalidUnit
+	i, err := defaultUnitRegistry.scanInterval(interval)
+	if err != nil {
+		return nil, err
 	}
-
-	return &Interval{Value: value, Unit: unit}, nil
+	return &i, nil
 }
 
 func MustParseInterval(interval string) *Interval {
@@ -44,6 +40,21 @@ func (i *Interval) String() string {
 	return strconv.FormatFloat(i.Value, 'f', -1, 64) + i.Unit.Short
 }
 
+// Humanize returns a human-readable form of the interval, e.g. "1.5 hours"
+func (i *Interval) Humanize() string {
+	return humanizeValue(i.Value, i.Unit)
+}
+
+// Convert expresses the interval in the given built-in unit, e.g. 90m in hours is 1.5h (see UnitRegistry.Convert)
+func (i *Interval) Convert(u Unit) (*Interval, error) {
+	return defaultUnitRegistry.Convert(i, u)
+}
+
+// Normalize expresses the interval in the longest unit giving a whole number, e.g. 120m is 2h (see UnitRegistry.Normalize)
+func (i *Interval) Normalize() *Interval {
+	return defaultUnitRegistry.Normalize(i)
+}
+
 // IsNil returns true if interval is nil
 func (i *Interval) IsNil() bool {
 	if i == nil {
@@ -57,12 +68,12 @@ func (i *Interval) IsNil() bool {
 // This method should be used to determine if the `Duration()` method can be safely called
 // on this Interval.
 //
-// Only seconds, minutes, hours, days, and weeks are precise.
+// Only nanoseconds, microseconds, milliseconds, seconds, minutes, hours, days, and weeks are precise.
 // Interval based on months and years may be too vague and therefore
 // converting them to a precise time.Duration is not possible.
 func (i *Interval) IsSafeDuration() bool {
 	switch i.Unit {
-	case UnitSecond, UnitMinute, UnitHour, UnitDay, UnitWeek:
+	case UnitNanosecond, UnitMicrosecond, UnitMillisecond, UnitSecond, UnitMinute, UnitHour, UnitDay, UnitWeek:
 		return true
 	default:
 		return false
@@ -73,6 +84,12 @@ func (i *Interval) IsSafeDuration() bool {
 // It will panic otherwise
 func (i *Interval) Duration() time.Duration {
 	switch i.Unit {
+	case UnitNanosecond:
+		return time.Duration(i.Value)
+	case UnitMicrosecond:
+		return time.Duration(i.Value * float64(time.Microsecond))
+	case UnitMillisecond:
+		return time.Duration(i.Value * float64(time.Millisecond))
 	case UnitSecond:
 		return time.Duration(i.Value * float64(time.Second))
 	case UnitMinute:
@@ -96,6 +113,8 @@ func (i *Interval) ExtractDateParts() (years int, months int, days int) {
 	switch i.Unit {
 	case UnitYear:
 		years = int(i.Value)
+	case UnitQuarter:
+		months = int(i.Value) * 3
 	case UnitMonth:
 		months = int(i.Value)
 	case UnitWeek:
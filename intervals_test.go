@@ -18,6 +18,11 @@ var _ = Describe("Intervals", func() {
 			Expect(interval.Unit).To(Equal(expectedUnit))
 		},
 			Entry("5 seconds", "5s", 5.0, epoch.UnitSecond),
+			Entry("250 milliseconds", "250ms", 250.0, epoch.UnitMillisecond),
+			Entry("500 microseconds", "500us", 500.0, epoch.UnitMicrosecond),
+			Entry("500 microseconds (micro sign)", "500µs", 500.0, epoch.UnitMicrosecond),
+			Entry("500 microseconds (greek mu)", "500μs", 500.0, epoch.UnitMicrosecond),
+			Entry("100 nanoseconds", "100ns", 100.0, epoch.UnitNanosecond),
 			Entry("3 minutes", "3m", 3.0, epoch.UnitMinute),
 			Entry("7 hours", "7h", 7.0, epoch.UnitHour),
 			Entry("2 days", "2d", 2.0, epoch.UnitDay),
@@ -38,6 +43,11 @@ var _ = Describe("Intervals", func() {
 			Entry("0 day", "0d", 0.0, epoch.UnitDay),
 			Entry("-2 week", "-2w", -2.0, epoch.UnitWeek),
 			Entry("-3 year", "-3y", -3.0, epoch.UnitYear),
+
+			Entry("exponent", "1e3s", 1000.0, epoch.UnitSecond),
+			Entry("negative exponent", "15E-1h", 1.5, epoch.UnitHour),
+			Entry("space before unit", "5 m", 5.0, epoch.UnitMinute),
+			Entry("surrounding spaces", " -2 mo ", -2.0, epoch.UnitMonth),
 		)
 
 		DescribeTable("invalid input is given", func(inputStr string, expectedError error) {
@@ -48,10 +58,10 @@ var _ = Describe("Intervals", func() {
 			Entry("empty input", "", epoch.ErrInvalidFormat),
 			Entry("invalid unit", "5x", epoch.ErrInvalidUnit),
 
-			// todo: it's actually ignores `.3`, should fail on InvalidFormat
-			//Entry("invalid value", "5.5.3m", epoch.ErrInvalidFormat),
+			Entry("invalid value", "5.5.3m", epoch.ErrInvalidFormat),
+			Entry("no value", "m", epoch.ErrInvalidFormat),
+			Entry("no unit", "5", epoch.ErrInvalidFormat),
 		)
-
 	})
 
 	Context("MustParseInterval", func() {
@@ -65,12 +75,74 @@ var _ = Describe("Intervals", func() {
 		})
 	})
 
+	Context("interval.String()", func() {
+		DescribeTable("renders value and short unit", func(input epoch.Interval, expected string) {
+			Expect(input.String()).To(Equal(expected))
+		},
+			Entry("milliseconds", epoch.Interval{250, epoch.UnitMillisecond}, "250ms"),
+			Entry("microseconds", epoch.Interval{500, epoch.UnitMicrosecond}, "500us"),
+			Entry("nanoseconds", epoch.Interval{100, epoch.UnitNanosecond}, "100ns"),
+			Entry("minutes", epoch.Interval{5, epoch.UnitMinute}, "5m"),
+			Entry("months", epoch.Interval{5, epoch.UnitMonth}, "5mo"),
+		)
+	})
+
+	Context("interval.Convert()", func() {
+		DescribeTable("converts into compatible units", func(input string, unit epoch.Unit, expected string) {
+			converted, err := epoch.MustParseInterval(input).Convert(unit)
+			Expect(err).To(Succeed())
+			Expect(converted.String()).To(Equal(expected))
+		},
+			Entry("minutes into hours", "90m", epoch.UnitHour, "1.5h"),
+			Entry("weeks into days", "2w", epoch.UnitDay, "14d"),
+			Entry("seconds into milliseconds", "1.5s", epoch.UnitMillisecond, "1500ms"),
+			Entry("years into months", "2y", epoch.UnitMonth, "24mo"),
+			Entry("months into quarters", "6mo", epoch.UnitQuarter, "2q"),
+		)
+
+		It("fails on incompatible units", func() {
+			_, err := epoch.MustParseInterval("1mo").Convert(epoch.UnitDay)
+			Expect(errors.Is(err, epoch.ErrIncompatibleUnits)).To(BeTrue())
+		})
+	})
+
+	Context("interval.Normalize()", func() {
+		DescribeTable("uses the longest unit giving a whole number", func(input string, expected string) {
+			Expect(epoch.MustParseInterval(input).Normalize().String()).To(Equal(expected))
+		},
+			Entry("minutes into hours", "120m", "2h"),
+			Entry("fractional hours into minutes", "1.5h", "90m"),
+			Entry("days into weeks", "14d", "2w"),
+			Entry("hours into days", "48h", "2d"),
+			Entry("months into years", "12mo", "1y"),
+			Entry("months into quarters", "6mo", "2q"),
+			Entry("negative", "-60s", "-1m"),
+			Entry("zero", "0h", "0h"),
+			Entry("business days", "3bd", "3bd"),
+		)
+	})
+
+	Context("UnitFactory", func() {
+		It("returns sub-second units", func() {
+			f := epoch.AvailableUnits.Factory()
+			Expect(f.Millisecond()).To(Equal(epoch.UnitMillisecond))
+			Expect(f.Microsecond()).To(Equal(epoch.UnitMicrosecond))
+			Expect(f.Nanosecond()).To(Equal(epoch.UnitNanosecond))
+			Expect(f.Minute()).To(Equal(epoch.UnitMinute))
+			Expect(f.Month()).To(Equal(epoch.UnitMonth))
+		})
+	})
+
 	Context("interval.Duration()", func() {
 		DescribeTable("valid input is given", func(input epoch.Interval, expectedDuration time.Duration) {
 			duration := input.Duration()
 			Expect(duration).To(Equal(expectedDuration), input.String())
 		},
 			Entry("5 seconds", epoch.Interval{5, epoch.UnitSecond}, 5*time.Second),
+			Entry("250 milliseconds", epoch.Interval{250, epoch.UnitMillisecond}, 250*time.Millisecond),
+			Entry("500 microseconds", epoch.Interval{500, epoch.UnitMicrosecond}, 500*time.Microsecond),
+			Entry("100 nanoseconds", epoch.Interval{100, epoch.UnitNanosecond}, 100*time.Nanosecond),
+			Entry("1.5 milliseconds", epoch.Interval{1.5, epoch.UnitMillisecond}, 1500*time.Microsecond),
 			Entry("5 minutes", epoch.Interval{5, epoch.UnitMinute}, 5*time.Minute),
 			Entry("5 hours", epoch.Interval{5, epoch.UnitHour}, 5*time.Hour),
 			Entry("5 days", epoch.Interval{5, epoch.UnitDay}, 5*24*time.Hour),
@@ -93,6 +165,9 @@ var _ = Describe("Intervals", func() {
 	Context("IsSafeDuration()", func() {
 		It("returns true for safe durations", func() {
 			intervals := []epoch.Interval{
+				{5, epoch.UnitNanosecond},
+				{5, epoch.UnitMicrosecond},
+				{5, epoch.UnitMillisecond},
 				{5, epoch.UnitSecond},
 				{5, epoch.UnitMinute},
 				{5, epoch.UnitHour},
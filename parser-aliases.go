@@ -12,7 +12,14 @@ type Alias struct {
 }
 
 func GetAliasDictionary() []Alias {
-	return []Alias{
+	aliases := []Alias{
+		{
+			Slug:        "now",
+			Description: "Current time",
+			Callback: func(now time.Time) time.Time {
+				return now
+			},
+		},
 		{
 			Slug:        "today",
 			Description: "Time of the start of today",
@@ -59,52 +66,77 @@ func GetAliasDictionary() []Alias {
 			},
 		},
 	}
+
+	return append(aliases, GetBusinessDayAliases(nil)...)
 }
 
 // AliasesParser parses alias strings like today, yesterday, etc
 type AliasesParser struct {
 	dictionary []Alias
 	clock      Clock
+	location   *time.Location
 }
 
-var _ Parser = &AliasesParser{}
+var _ RelativeParser = &AliasesParser{}
+var _ CloneableParser = &AliasesParser{}
+var _ ClockAware = &AliasesParser{}
+var _ LocationAware = &AliasesParser{}
+var _ TryParser = &AliasesParser{}
 
 var (
 	ParserNameAliases = "aliases"
 )
 
 func (a *AliasesParser) Match(s string) bool {
+	_, ok := a.lookup(s)
+	return ok
+}
+
+func (a *AliasesParser) lookup(s string) (Alias, bool) {
 	for _, alias := range a.dictionary {
 		if s == alias.Slug {
-			return true
+			return alias, true
 		}
 	}
-	return false
+	return Alias{}, false
 }
 
 func (a *AliasesParser) Parse(s string, locArg ...*time.Location) (time.Time, *ParseDetails, error) {
-	var loc *time.Location
-	if len(locArg) > 0 {
-		loc = locArg[0]
+	return a.ParseWithClock(s, a.clock, locArg...)
+}
+
+// ParseWithClock resolves the alias against the time of the given clock
+func (a *AliasesParser) ParseWithClock(s string, clock Clock, locArg ...*time.Location) (time.Time, *ParseDetails, error) {
+	alias, ok := a.lookup(s)
+	if !ok {
+		return time.Time{}, nil, fmt.Errorf("alias not found")
 	}
 
-	for _, alias := range a.dictionary {
-		if s != alias.Slug {
-			continue
-		}
+	t, details := a.resolve(alias, clock, locArg)
+	return t, details, nil
+}
 
-		now := a.clock.Now()
-		if loc != nil {
-			now = now.In(loc)
-		}
-		return alias.Callback(now), &ParseDetails{
-			IsRelative: true,
-			IsAliased:  true,
-			ParserName: ParserNameAliases,
-		}, nil
+// TryParse is the same as Match and Parse in a single pass
+func (a *AliasesParser) TryParse(s string, locArg ...*time.Location) (time.Time, *ParseDetails, bool, error) {
+	alias, ok := a.lookup(s)
+	if !ok {
+		return time.Time{}, nil, false, nil
 	}
 
-	return time.Time{}, nil, fmt.Errorf("alias not found")
+	t, details := a.resolve(alias, a.clock, locArg)
+	return t, details, true, nil
+}
+
+func (a *AliasesParser) resolve(alias Alias, clock Clock, locArg []*time.Location) (time.Time, *ParseDetails) {
+	now := clock.Now()
+	if loc := locationArg(locArg, a.location); loc != nil {
+		now = now.In(loc)
+	}
+	return alias.Callback(now), &ParseDetails{
+		IsRelative: true,
+		IsAliased:  true,
+		ParserName: ParserNameAliases,
+	}
 }
 
 func (a *AliasesParser) GetDictionary() []Alias {
@@ -115,11 +147,41 @@ func (a *AliasesParser) ExpandDictionary(aliases ...Alias) {
 	a.dictionary = append(a.dictionary, aliases...)
 }
 
+// SetHolidayCalendar makes business-day aliases (e.g. "last-business-day") use the given calendar
+func (a *AliasesParser) SetHolidayCalendar(cal HolidayCalendar) *AliasesParser {
+	for _, alias := range GetBusinessDayAliases(cal) {
+		for i := range a.dictionary {
+			if a.dictionary[i].Slug == alias.Slug {
+				a.dictionary[i] = alias
+			}
+		}
+	}
+	return a
+}
+
 func (a *AliasesParser) SetClock(c Clock) *AliasesParser {
 	a.clock = c
 	return a
 }
 
+// UseClock sets the clock aliases are resolved against (same as SetClock)
+func (a *AliasesParser) UseClock(c Clock) {
+	a.SetClock(c)
+}
+
+// UseLocation sets the location aliases are resolved in when no locArg is given
+// (the location of the clock's time is used by default)
+func (a *AliasesParser) UseLocation(loc *time.Location) {
+	a.location = loc
+}
+
+// Clone returns a copy of the parser with its own dictionary
+func (a *AliasesParser) Clone() Parser {
+	c := *a
+	c.dictionary = append([]Alias(nil), a.dictionary...)
+	return &c
+}
+
 func NewAliasesParser() *AliasesParser {
 	return &AliasesParser{
 		dictionary: GetAliasDictionary(),
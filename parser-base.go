@@ -1,14 +1,22 @@
 package epoch
 
 import (
+	"errors"
 	"fmt"
 	"time"
 )
 
 // BaseParser parses time in a specified format (defaulted to time.RFC3339)
-type BaseParser struct{}
+type BaseParser struct {
+	dstPolicy     DSTPolicy
+	abbreviations *AbbreviationResolver
+	location      *time.Location
+}
 
 var _ Parser = &BaseParser{}
+var _ LocationAware = &BaseParser{}
+var _ TryParser = &BaseParser{}
+var _ CloneableParser = &BaseParser{}
 
 var (
 	ParserNameBase = "base"
@@ -25,6 +33,39 @@ func NewBaseParser() *BaseParser {
 	return &BaseParser{}
 }
 
+// SetDSTPolicy sets how nonexistent and ambiguous wall clock times are resolved
+// when a time without an offset is parsed in a given location
+func (b *BaseParser) SetDSTPolicy(p DSTPolicy) *BaseParser {
+	b.dstPolicy = p
+	return b
+}
+
+// SetAbbreviationResolver sets the resolver used for timezone abbreviations unknown to the time package
+// (DefaultAbbreviationResolver is used by default)
+func (b *BaseParser) SetAbbreviationResolver(r *AbbreviationResolver) *BaseParser {
+	b.abbreviations = r
+	return b
+}
+
+func (b *BaseParser) abbreviationResolver() *AbbreviationResolver {
+	if b.abbreviations == nil {
+		return DefaultAbbreviationResolver
+	}
+	return b.abbreviations
+}
+
+// Clone returns a copy of the parser
+func (b *BaseParser) Clone() Parser {
+	c := *b
+	return &c
+}
+
+// UseLocation sets the location used when no locArg is given
+// (strings without an offset are parsed in it)
+func (b *BaseParser) UseLocation(loc *time.Location) {
+	b.location = loc
+}
+
 // Match checks if given string is in the specified format
 func (b *BaseParser) Match(s string) bool {
 	_, err := time.Parse(BaseParserFormat, s)
@@ -33,28 +74,85 @@ func (b *BaseParser) Match(s string) bool {
 
 // Parse converts string to time.Time
 func (b *BaseParser) Parse(s string, locArg ...*time.Location) (time.Time, *ParseDetails, error) {
-	var loc *time.Location
-	if len(locArg) > 0 {
-		loc = locArg[0]
+	loc := locationArg(locArg, b.location)
+
+	t, err := b.parseFormat(s, loc)
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("failed to parse time in format %s: %w", BaseParserFormat, err)
 	}
 
-	var t time.Time
-	var err error
+	return b.parse(s, t, loc)
+}
+
+// TryParse is the same as Match and Parse in a single pass
+func (b *BaseParser) TryParse(s string, locArg ...*time.Location) (time.Time, *ParseDetails, bool, error) {
+	loc := locationArg(locArg, b.location)
+
+	// the string matches the format if and only if it can be parsed in it (whatever the location is)
+	t, err := b.parseFormat(s, loc)
+	if err != nil {
+		return time.Time{}, nil, false, nil
+	}
+
+	t, details, err := b.parse(s, t, loc)
+	return t, details, true, err
+}
+
+func (b *BaseParser) parseFormat(s string, loc *time.Location) (time.Time, error) {
 	if loc != nil {
-		t, err = time.ParseInLocation(BaseParserFormat, s, loc)
-	} else {
-		t, err = time.Parse(BaseParserFormat, s)
+		return time.ParseInLocation(BaseParserFormat, s, loc)
 	}
-	if err != nil {
-		return time.Time{}, nil, fmt.Errorf("failed to parse time in format %s: %w", BaseParserFormat, err)
+	return time.Parse(BaseParserFormat, s)
+}
+
+// parse resolves zones of t that is parsed from s in loc (nil if no location is given)
+func (b *BaseParser) parse(s string, t time.Time, loc *time.Location) (time.Time, *ParseDetails, error) {
+	var err error
+	details := &ParseDetails{
+		ParserName: ParserNameBase,
+		Format:     BaseParserFormat,
+	}
+
+	// the time package gives a zero offset to abbreviations it doesn't know (e.g. "CEST").
+	// Ambiguous ones are always resolved by the resolver; others are loaded as locations first (see below)
+	if zone, ok := fabricatedZone(t, loc); ok && (loc != nil || b.abbreviationResolver().IsAmbiguous(zone)) {
+		resolved, res, err := b.resolveAbbreviation(t, zone)
+		if err != nil {
+			return time.Time{}, nil, err
+		}
+		if res != nil {
+			details.Abbreviation = res
+			return resolved, details, nil
+		}
+	}
+
+	if loc != nil && b.dstPolicy != DSTPolicyNone {
+		t, err = b.resolveWallClock(s, t, loc)
+		if err != nil {
+			return time.Time{}, nil, err
+		}
 	}
 
 	// if no location is given
-	// load the location from the parsed time
-	if loc == nil && t.Location() != nil {
+	// load the location from the parsed time.
+	// UTC and Local are loaded as themselves, so parsing in them again gives the same time
+	if loc == nil && t.Location() != nil && t.Location() != time.UTC && t.Location() != time.Local {
 		loc, err = time.LoadLocation(t.Location().String())
 		if err != nil {
-			return time.Time{}, nil, fmt.Errorf("invalid location specified: %w", err)
+			zone, ok := fabricatedZone(t, nil)
+			if !ok {
+				return time.Time{}, nil, fmt.Errorf("invalid location specified: %w", err)
+			}
+
+			resolved, res, rerr := b.resolveAbbreviation(t, zone)
+			if rerr != nil {
+				return time.Time{}, nil, rerr
+			}
+			if res == nil {
+				return time.Time{}, nil, fmt.Errorf("invalid location specified: %w", err)
+			}
+			details.Abbreviation = res
+			return resolved, details, nil
 		}
 
 		t, err = time.ParseInLocation(BaseParserFormat, s, loc)
@@ -63,10 +161,41 @@ func (b *BaseParser) Parse(s string, locArg ...*time.Location) (time.Time, *Pars
 		}
 	}
 
-	return t, &ParseDetails{
-		ParserName: ParserNameBase,
-		Format:     BaseParserFormat,
-	}, nil
+	return t, details, nil
+}
+
+// resolveAbbreviation moves the wall clock of t into the zone the abbreviation stands for.
+// Nil resolution (and no error) is returned for abbreviations unknown to the resolver.
+func (b *BaseParser) resolveAbbreviation(t time.Time, zone string) (time.Time, *AbbreviationResolution, error) {
+	res, err := b.abbreviationResolver().Resolve(zone)
+	if errors.Is(err, ErrUnknownAbbreviation) {
+		return t, nil, nil
+	}
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	y, mo, d := t.Date()
+	h, mi, sec := t.Clock()
+	return time.Date(y, mo, d, h, mi, sec, t.Nanosecond(), res.Location()), res, nil
+}
+
+// offsetSentinel is a location no string can give the offset of (its offset has seconds),
+// so times parsed in it have no offset of their own
+var offsetSentinel = time.FixedZone("epoch-sentinel", -(11*3600 + 59*60 + 59))
+
+// resolveWallClock re-resolves t parsed in loc using the DST policy,
+// unless the string carries its own offset
+func (b *BaseParser) resolveWallClock(s string, t time.Time, loc *time.Location) (time.Time, error) {
+	sentinel, err := time.ParseInLocation(BaseParserFormat, s, offsetSentinel)
+	if err != nil || sentinel.Location() != offsetSentinel {
+		return t, nil
+	}
+
+	y, mo, d := sentinel.Date()
+	h, mi, sec := sentinel.Clock()
+	wall := time.Date(y, mo, d, h, mi, sec, sentinel.Nanosecond(), time.UTC)
+	return ResolveLocalTime(wall, loc, b.dstPolicy)
 }
 
 // Name returns the name of the parser, "base"
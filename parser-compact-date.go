@@ -0,0 +1,81 @@
+package epoch
+
+import (
+	"fmt"
+	"time"
+)
+
+// CompactDateParser parses dates in the compact (basic ISO 8601) format, e.g. "20240101"
+type CompactDateParser struct {
+	location *time.Location
+}
+
+var _ Parser = &CompactDateParser{}
+var _ LocationAware = &CompactDateParser{}
+var _ TryParser = &CompactDateParser{}
+var _ CloneableParser = &CompactDateParser{}
+
+var (
+	ParserNameCompactDate = "compact-date"
+)
+
+const compactDateFormat = "20060102"
+
+// NewCompactDateParser returns a new CompactDateParser
+func NewCompactDateParser() *CompactDateParser {
+	return &CompactDateParser{}
+}
+
+// Clone returns a copy of the parser
+func (c *CompactDateParser) Clone() Parser {
+	clone := *c
+	return &clone
+}
+
+// UseLocation sets the location of parsed dates when no locArg is given (UTC by default)
+func (c *CompactDateParser) UseLocation(loc *time.Location) {
+	c.location = loc
+}
+
+// Match checks if given string is a valid date in the format of YYYYMMDD
+func (c *CompactDateParser) Match(s string) bool {
+	if len(s) != len(compactDateFormat) {
+		return false
+	}
+	_, err := time.Parse(compactDateFormat, s)
+	return err == nil
+}
+
+// Parse converts string to time.Time (the start of the day)
+func (c *CompactDateParser) Parse(s string, locArg ...*time.Location) (time.Time, *ParseDetails, error) {
+	loc := locationArg(locArg, c.location)
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	t, err := time.ParseInLocation(compactDateFormat, s, loc)
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("failed to parse compact date: %w", err)
+	}
+	return t, &ParseDetails{
+		ParserName: ParserNameCompactDate,
+		Format:     compactDateFormat,
+	}, nil
+}
+
+// TryParse is the same as Match and Parse in a single pass
+func (c *CompactDateParser) TryParse(s string, locArg ...*time.Location) (time.Time, *ParseDetails, bool, error) {
+	if len(s) != len(compactDateFormat) {
+		return time.Time{}, nil, false, nil
+	}
+	t, details, err := c.Parse(s, locArg...)
+	if err != nil {
+		return time.Time{}, nil, false, nil
+	}
+	return t, details, true, nil
+}
+
+// Name returns the name of the parser, "compact-date"
+func (c *CompactDateParser) Name() string {
+	return ParserNameCompactDate
+}
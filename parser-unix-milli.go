@@ -7,9 +7,14 @@ import (
 )
 
 // UnixMilliParser parses unix timestamp in milliseconds
-type UnixMilliParser struct{}
+type UnixMilliParser struct {
+	location *time.Location
+}
 
 var _ Parser = &UnixMilliParser{}
+var _ LocationAware = &UnixMilliParser{}
+var _ TryParser = &UnixMilliParser{}
+var _ CloneableParser = &UnixMilliParser{}
 
 var (
 	ParserNameUnixMilli = "unix-milli"
@@ -20,6 +25,17 @@ func NewUnixMilliParser() *UnixMilliParser {
 	return &UnixMilliParser{}
 }
 
+// Clone returns a copy of the parser
+func (u *UnixMilliParser) Clone() Parser {
+	c := *u
+	return &c
+}
+
+// UseLocation sets the location of parsed times when no locArg is given (UTC by default)
+func (u *UnixMilliParser) UseLocation(loc *time.Location) {
+	u.location = loc
+}
+
 // Match checks if given string is unix timestamp in milliseconds
 func (u *UnixMilliParser) Match(s string) bool {
 	_, err := strconv.ParseInt(s, 10, 64)
@@ -28,21 +44,39 @@ func (u *UnixMilliParser) Match(s string) bool {
 
 // Parse converts string to time.Time
 func (u *UnixMilliParser) Parse(s string, locArg ...*time.Location) (time.Time, *ParseDetails, error) {
-	loc := time.UTC
-	if len(locArg) > 0 {
-		loc = locArg[0]
-	}
-
 	i, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
 		return time.Time{}, nil, fmt.Errorf("failed to parse unix milliseconds time: %w", err)
 	}
 
-	return time.Unix(0, i*int64(time.Millisecond)).In(loc), &ParseDetails{
+	return u.time(i, locArg), &ParseDetails{
 		ParserName: ParserNameUnixMilli,
 	}, nil
 }
 
+// TryParse is the same as Match and Parse in a single pass
+func (u *UnixMilliParser) TryParse(s string, locArg ...*time.Location) (time.Time, *ParseDetails, bool, error) {
+	if len(s) < 11 {
+		return time.Time{}, nil, false, nil
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, nil, false, nil
+	}
+
+	return u.time(i, locArg), &ParseDetails{
+		ParserName: ParserNameUnixMilli,
+	}, true, nil
+}
+
+func (u *UnixMilliParser) time(ms int64, locArg []*time.Location) time.Time {
+	loc := locationArg(locArg, u.location)
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.Unix(0, ms*int64(time.Millisecond)).In(loc)
+}
+
 // Name returns the name of the parser, "unix-milli"
 func (u *UnixMilliParser) Name() string {
 	return ParserNameUnixMilli
@@ -7,9 +7,14 @@ import (
 )
 
 // UnixSecondsParser parses unix timestamp in seconds
-type UnixSecondsParser struct{}
+type UnixSecondsParser struct {
+	location *time.Location
+}
 
 var _ Parser = &UnixSecondsParser{}
+var _ LocationAware = &UnixSecondsParser{}
+var _ TryParser = &UnixSecondsParser{}
+var _ CloneableParser = &UnixSecondsParser{}
 
 var (
 	ParserNameUnixSeconds = "unix-seconds"
@@ -20,6 +25,17 @@ func NewUnixSecondsParser() *UnixSecondsParser {
 	return &UnixSecondsParser{}
 }
 
+// Clone returns a copy of the parser
+func (u *UnixSecondsParser) Clone() Parser {
+	c := *u
+	return &c
+}
+
+// UseLocation sets the location of parsed times when no locArg is given (UTC by default)
+func (u *UnixSecondsParser) UseLocation(loc *time.Location) {
+	u.location = loc
+}
+
 // Match checks if given string is unix timestamp in seconds
 func (u *UnixSecondsParser) Match(s string) bool {
 	_, err := strconv.ParseInt(s, 10, 64)
@@ -28,20 +44,37 @@ func (u *UnixSecondsParser) Match(s string) bool {
 
 // Parse converts string to time.Time
 func (u *UnixSecondsParser) Parse(s string, locArg ...*time.Location) (time.Time, *ParseDetails, error) {
-	loc := time.UTC
-	if len(locArg) > 0 {
-		loc = locArg[0]
-	}
-
 	i, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
 		return time.Time{}, nil, fmt.Errorf("failed to parse unix seconds time: %w", err)
 	}
-	return time.Unix(i, 0).In(loc), &ParseDetails{
+	return u.time(i, locArg), &ParseDetails{
 		ParserName: ParserNameUnixSeconds,
 	}, nil
 }
 
+// TryParse is the same as Match and Parse in a single pass
+func (u *UnixSecondsParser) TryParse(s string, locArg ...*time.Location) (time.Time, *ParseDetails, bool, error) {
+	if len(s) >= 11 {
+		return time.Time{}, nil, false, nil
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, nil, false, nil
+	}
+	return u.time(i, locArg), &ParseDetails{
+		ParserName: ParserNameUnixSeconds,
+	}, true, nil
+}
+
+func (u *UnixSecondsParser) time(sec int64, locArg []*time.Location) time.Time {
+	loc := locationArg(locArg, u.location)
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.Unix(sec, 0).In(loc)
+}
+
 // Name returns the name of the parser, "unix-seconds"
 func (u *UnixSecondsParser) Name() string {
 	return ParserNameUnixSeconds
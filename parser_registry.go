@@ -0,0 +1,266 @@
+package epoch
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrParserExists   = fmt.Errorf("parser already registered")
+	ErrParserNotFound = fmt.Errorf("parser not found")
+)
+
+// parserEntry is a parser registered in TimeParser
+type parserEntry struct {
+	parser   Parser
+	enabled  bool
+	priority int
+}
+
+// activeParsers returns enabled parsers in order of matching.
+// The returned slice is never modified, so it can be used without holding the lock
+func (tp *TimeParser) activeParsers() []Parser {
+	tp.mu.RLock()
+	defer tp.mu.RUnlock()
+
+	return tp.parsers
+}
+
+// rebuild recalculates the list of active parsers: higher priority goes first,
+// parsers with the same priority keep the order of registration.
+// It must be called with the lock held.
+func (tp *TimeParser) rebuild() {
+	entries := make([]*parserEntry, len(tp.registry))
+	copy(entries, tp.registry)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].priority > entries[j].priority
+	})
+
+	parsers := make([]Parser, 0, len(entries))
+	for _, e := range entries {
+		if e.enabled {
+			parsers = append(parsers, e.parser)
+		}
+	}
+	tp.parsers = parsers
+}
+
+// findEntries returns all entries with the given name. It must be called with the lock held.
+func (tp *TimeParser) findEntries(name string) []*parserEntry {
+	var found []*parserEntry
+	for _, e := range tp.registry {
+		if e.parser.Name() == name {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// Register adds an enabled parser with zero priority after all registered ones.
+// The clock and the location of TimeParser are propagated to a copy of it (see WithClock, WithLocation and CloneableParser).
+func (tp *TimeParser) Register(parser Parser) error {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	if len(tp.findEntries(parser.Name())) > 0 {
+		return fmt.Errorf("%w: %s", ErrParserExists, parser.Name())
+	}
+
+	tp.registry = append(tp.registry, &parserEntry{parser: tp.configureParser(parser), enabled: true})
+	tp.rebuild()
+	return nil
+}
+
+// Unregister removes the parser with the given name
+func (tp *TimeParser) Unregister(name string) error {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	registry := tp.registry[:0:0]
+	for _, e := range tp.registry {
+		if e.parser.Name() != name {
+			registry = append(registry, e)
+		}
+	}
+	if len(registry) == len(tp.registry) {
+		return fmt.Errorf("%w: %s", ErrParserNotFound, name)
+	}
+
+	tp.registry = registry
+	tp.rebuild()
+	return nil
+}
+
+// Enable enables the parser with the given name
+func (tp *TimeParser) Enable(name string) error {
+	return tp.updateEntries(name, func(e *parserEntry) { e.enabled = true })
+}
+
+// Disable disables the parser with the given name, it's kept registered though
+func (tp *TimeParser) Disable(name string) error {
+	return tp.updateEntries(name, func(e *parserEntry) { e.enabled = false })
+}
+
+// SetPriority sets the priority of the parser with the given name.
+// Parsers with higher priority are matched first, default priority is zero.
+func (tp *TimeParser) SetPriority(name string, priority int) error {
+	return tp.updateEntries(name, func(e *parserEntry) { e.priority = priority })
+}
+
+// SetOrder puts the given parsers first in the given order; the rest keep their relative order after them
+func (tp *TimeParser) SetOrder(names ...string) error {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	for _, name := range names {
+		if len(tp.findEntries(name)) == 0 {
+			return fmt.Errorf("%w: %s", ErrParserNotFound, name)
+		}
+	}
+
+	for _, e := range tp.registry {
+		e.priority = 0
+	}
+	for i, name := range names {
+		for _, e := range tp.findEntries(name) {
+			e.priority = len(names) - i
+		}
+	}
+	tp.rebuild()
+	return nil
+}
+
+func (tp *TimeParser) updateEntries(name string, update func(e *parserEntry)) error {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	entries := tp.findEntries(name)
+	if len(entries) == 0 {
+		return fmt.Errorf("%w: %s", ErrParserNotFound, name)
+	}
+
+	for _, e := range entries {
+		update(e)
+	}
+	tp.rebuild()
+	return nil
+}
+
+// ActiveParsers returns names of enabled parsers in order of matching
+func (tp *TimeParser) ActiveParsers() []string {
+	parsers := tp.activeParsers()
+	names := make([]string, 0, len(parsers))
+	for _, p := range parsers {
+		names = append(names, p.Name())
+	}
+	return names
+}
+
+// RegisteredParsers returns names of all registered parsers (including disabled ones) in order of registration
+func (tp *TimeParser) RegisteredParsers() []string {
+	tp.mu.RLock()
+	defer tp.mu.RUnlock()
+
+	names := make([]string, 0, len(tp.registry))
+	for _, e := range tp.registry {
+		names = append(names, e.parser.Name())
+	}
+	return names
+}
+
+// GetParser returns the registered parser with the given name
+func (tp *TimeParser) GetParser(name string) (Parser, bool) {
+	tp.mu.RLock()
+	defer tp.mu.RUnlock()
+
+	entries := tp.findEntries(name)
+	if len(entries) == 0 {
+		return nil, false
+	}
+	return entries[0].parser, true
+}
+
+var (
+	parserFactoriesMu sync.RWMutex
+	parserFactories   = map[string]func() Parser{
+		ParserNameBase:        func() Parser { return NewBaseParser() },
+		ParserNameUnixSeconds: func() Parser { return NewUnixSecondsParser() },
+		ParserNameUnixMilli:   func() Parser { return NewUnixMilliParser() },
+		ParserNameCompactDate: func() Parser { return NewCompactDateParser() },
+		ParserNameAliases:     func() Parser { return NewAliasesParser() },
+	}
+)
+
+// RegisterParserFactory makes a user-defined parser available by name to configs (see TimeParserConfig)
+func RegisterParserFactory(name string, factory func() Parser) {
+	parserFactoriesMu.Lock()
+	defer parserFactoriesMu.Unlock()
+
+	parserFactories[name] = factory
+}
+
+// NewParsersByName creates new parsers by their names, e.g. "base", "unix-seconds", "aliases"
+func NewParsersByName(names ...string) ([]Parser, error) {
+	parserFactoriesMu.RLock()
+	defer parserFactoriesMu.RUnlock()
+
+	parsers := make([]Parser, 0, len(names))
+	for _, name := range names {
+		factory, ok := parserFactories[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrParserNotFound, name)
+		}
+		parsers = append(parsers, factory())
+	}
+	return parsers, nil
+}
+
+// TimeParserConfig describes a TimeParser, so it can be loaded from a config file (e.g. YAML or JSON)
+type TimeParserConfig struct {
+	// Parsers are names of parsers in order of matching, default parsers are used if it's empty
+	Parsers []string `json:"parsers" yaml:"parsers"`
+	// Disabled are names of parsers that are registered, but disabled
+	Disabled []string `json:"disabled" yaml:"disabled"`
+	// IntervalArithmetics enables interval arithmetics (see WithIntervalArithmetics)
+	IntervalArithmetics bool `json:"interval_arithmetics" yaml:"interval_arithmetics"`
+	// Location is the name of the default location (see WithLocation)
+	Location string `json:"location" yaml:"location"`
+	// Strict makes ambiguous inputs fail (see WithStrictAmbiguity)
+	Strict bool `json:"strict" yaml:"strict"`
+}
+
+// NewTimeParserFromConfig creates a TimeParser described by the config.
+// The given options are applied after the config.
+func NewTimeParserFromConfig(cfg TimeParserConfig, options ...TimeParserOption) (*TimeParser, error) {
+	var configOptions []TimeParserOption
+	if len(cfg.Parsers) > 0 {
+		parsers, err := NewParsersByName(cfg.Parsers...)
+		if err != nil {
+			return nil, err
+		}
+		configOptions = append(configOptions, WithParsers(parsers...))
+	}
+	if cfg.IntervalArithmetics {
+		configOptions = append(configOptions, WithIntervalArithmetics())
+	}
+	if cfg.Strict {
+		configOptions = append(configOptions, WithStrictAmbiguity())
+	}
+	if cfg.Location != "" {
+		loc, err := time.LoadLocation(cfg.Location)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidLocation, err)
+		}
+		configOptions = append(configOptions, WithLocation(loc))
+	}
+
+	tp := NewTimeParser(append(configOptions, options...)...)
+	for _, name := range cfg.Disabled {
+		if err := tp.Disable(name); err != nil {
+			return nil, err
+		}
+	}
+	return tp, nil
+}
@@ -0,0 +1,112 @@
+package epoch_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/aahainc/epoch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Parser registry", func() {
+	var p *epoch.TimeParser
+	BeforeEach(func() {
+		epoch.BaseParserFormat = time.RFC3339
+		p = epoch.NewTimeParser()
+	})
+
+	It("lists default parsers in order of matching", func() {
+		Expect(p.ActiveParsers()).To(Equal([]string{"base", "unix-seconds", "aliases"}))
+		Expect(p.RegisteredParsers()).To(Equal(p.ActiveParsers()))
+	})
+
+	It("registers and unregisters parsers", func() {
+		Expect(p.Register(epoch.NewUnixMilliParser())).To(Succeed())
+		Expect(p.ActiveParsers()).To(Equal([]string{"base", "unix-seconds", "aliases", "unix-milli"}))
+		Expect(p.Register(epoch.NewUnixMilliParser())).To(MatchError(epoch.ErrParserExists))
+
+		Expect(p.Unregister("aliases")).To(Succeed())
+		Expect(p.ActiveParsers()).To(Equal([]string{"base", "unix-seconds", "unix-milli"}))
+		Expect(p.Unregister("aliases")).To(MatchError(epoch.ErrParserNotFound))
+
+		_, err := p.Parse("today")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("disables and enables parsers", func() {
+		Expect(p.Disable("unix-seconds")).To(Succeed())
+		Expect(p.ActiveParsers()).To(Equal([]string{"base", "aliases"}))
+		Expect(p.RegisteredParsers()).To(ContainElement("unix-seconds"))
+
+		_, err := p.Parse("1577829600")
+		Expect(err).To(HaveOccurred())
+
+		Expect(p.Enable("unix-seconds")).To(Succeed())
+		_, err = p.Parse("1577829600")
+		Expect(err).To(Succeed())
+
+		Expect(p.Disable("unknown")).To(MatchError(epoch.ErrParserNotFound))
+	})
+
+	It("orders parsers by priority", func() {
+		Expect(p.Register(epoch.NewUnixMilliParser())).To(Succeed())
+		Expect(p.SetPriority("unix-milli", 10)).To(Succeed())
+		Expect(p.ActiveParsers()).To(Equal([]string{"unix-milli", "base", "unix-seconds", "aliases"}))
+
+		// milliseconds parser wins over seconds one now
+		t, err := p.Parse("1577829600000")
+		Expect(err).To(Succeed())
+		Expect(t.UnixMilli()).To(Equal(int64(1577829600000)))
+
+		Expect(p.SetOrder("aliases", "base")).To(Succeed())
+		Expect(p.ActiveParsers()).To(Equal([]string{"aliases", "base", "unix-seconds", "unix-milli"}))
+		Expect(p.SetOrder("unknown")).To(MatchError(epoch.ErrParserNotFound))
+	})
+
+	It("returns registered parsers by name", func() {
+		parser, ok := p.GetParser("aliases")
+		Expect(ok).To(BeTrue())
+		Expect(parser.Name()).To(Equal("aliases"))
+
+		_, ok = p.GetParser("unknown")
+		Expect(ok).To(BeFalse())
+	})
+
+	Context("Config", func() {
+		It("builds a parser from the config", func() {
+			var cfg epoch.TimeParserConfig
+			Expect(json.Unmarshal([]byte(`{
+				"parsers": ["unix-milli", "base", "aliases"],
+				"disabled": ["aliases"],
+				"interval_arithmetics": true,
+				"location": "Asia/Tokyo"
+			}`), &cfg)).To(Succeed())
+
+			p, err := epoch.NewTimeParserFromConfig(cfg)
+			Expect(err).To(Succeed())
+			Expect(p.ActiveParsers()).To(Equal([]string{"unix-milli", "base"}))
+			Expect(p.Location().String()).To(Equal("Asia/Tokyo"))
+
+			t, err := p.Parse("2020-01-01T00:00:00Z,1d")
+			Expect(err).To(Succeed())
+			Expect(t.UTC()).To(Equal(time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("uses user-defined parsers by name", func() {
+			epoch.RegisterParserFactory("recording", func() epoch.Parser { return &recordingParser{} })
+
+			p, err := epoch.NewTimeParserFromConfig(epoch.TimeParserConfig{Parsers: []string{"recording"}})
+			Expect(err).To(Succeed())
+			Expect(p.ActiveParsers()).To(Equal([]string{"recording"}))
+		})
+
+		It("fails on unknown parsers and locations", func() {
+			_, err := epoch.NewTimeParserFromConfig(epoch.TimeParserConfig{Parsers: []string{"unknown"}})
+			Expect(err).To(MatchError(epoch.ErrParserNotFound))
+
+			_, err = epoch.NewTimeParserFromConfig(epoch.TimeParserConfig{Location: "Mars/Olympus"})
+			Expect(err).To(MatchError(epoch.ErrInvalidLocation))
+		})
+	})
+})
@@ -15,6 +15,80 @@ type Parser interface {
 	Name() string
 }
 
+// TryParser is implemented by parsers that can check and parse the string in a single pass,
+// so TimeParser doesn't do the work twice (Match and then Parse)
+type TryParser interface {
+	Parser
+	// TryParse returns ok=false (and no error) if the string isn't in the parser's format, i.e. Match would fail.
+	// Otherwise, it's the same as Parse.
+	TryParse(s string, locArg ...*time.Location) (t time.Time, details *ParseDetails, ok bool, err error)
+}
+
+// tryParse parses the string with the given parser in a single pass if it supports TryParser
+func tryParse(p Parser, s string, locArg ...*time.Location) (time.Time, *ParseDetails, bool, error) {
+	if tp, ok := p.(TryParser); ok {
+		t, details, ok, err := tp.TryParse(s, locArg...)
+		if ok && err == nil {
+			details = detailsOrDefault(p, details)
+		}
+		return t, details, ok, err
+	}
+
+	if !p.Match(s) {
+		return time.Time{}, nil, false, nil
+	}
+	t, details, err := p.Parse(s, locArg...)
+	if err == nil {
+		details = detailsOrDefault(p, details)
+	}
+	return t, details, true, err
+}
+
+// detailsOrDefault returns the details given by the parser, user-defined parsers may return nil ones
+func detailsOrDefault(p Parser, details *ParseDetails) *ParseDetails {
+	if details == nil {
+		return &ParseDetails{ParserName: p.Name()}
+	}
+	return details
+}
+
+// RelativeParser is implemented by parsers whose result depends on the current time (e.g. aliases),
+// so they can be evaluated against a given clock instead of their own one
+type RelativeParser interface {
+	Parser
+	// ParseWithClock is the same as Parse but takes the current time from the given clock
+	ParseWithClock(s string, clock Clock, locArg ...*time.Location) (time.Time, *ParseDetails, error)
+}
+
+// ClockAware is implemented by parsers that depend on the current time,
+// so TimeParser can propagate its clock to them (see WithClock).
+// The parser must also implement CloneableParser: TimeParser configures its own copy.
+type ClockAware interface {
+	UseClock(c Clock)
+}
+
+// LocationAware is implemented by parsers that can fall back to a default location
+// when no locArg is given, so TimeParser can propagate its location to them (see WithLocation).
+// The parser must also implement CloneableParser: TimeParser configures its own copy.
+type LocationAware interface {
+	UseLocation(loc *time.Location)
+}
+
+// CloneableParser is implemented by parsers that can be copied, so a TimeParser configures
+// its own copy and parsers shared by several TimeParsers are never mutated
+type CloneableParser interface {
+	Parser
+	Clone() Parser
+}
+
+// locationArg returns the first non-nil location of locArg or the default one
+func locationArg(locArg []*time.Location, def *time.Location) *time.Location {
+	if len(locArg) > 0 && locArg[0] != nil {
+		return locArg[0]
+	}
+	return def
+}
+
 // ParseDetails stores details of parsing.
 type ParseDetails struct {
 	// ParserName is the name of the parser that was chosen
@@ -25,8 +99,15 @@ type ParseDetails struct {
 	IsAliased bool `json:"is_aliased"`
 	// Format stores the format used for parsing a formatted time.
 	Format string `json:"format"`
+	// Location is the name of the location of the resulting time
+	// (given by a zone qualifier like "today@Europe/Berlin", by locArg or by the parsed string itself)
+	Location string `json:"location,omitempty"`
+	// Abbreviation describes how a timezone abbreviation (e.g. "IST") found in the string was resolved
+	Abbreviation *AbbreviationResolution `json:"abbreviation,omitempty"`
 	// Arithmetics stores information about arithmetic operations applied to parsed time
 	Arithmetics *Arithmetics `json:"arithmetics,omitempty"`
+	// Trace records how the time was computed step by step (only if WithTrace is given)
+	Trace *Trace `json:"trace,omitempty"`
 }
 
 // Arithmetics holds information about any arithmetic operations applied on a parsed time
@@ -35,9 +116,12 @@ type Arithmetics struct {
 	Intervals []Interval `json:"intervals"`
 	// RawIntervals is a list of the raw interval strings used in arithmetic operations
 	RawIntervals []string `json:"raw_intervals"`
+	// Rounding is a list of short names of units the time was truncated to (e.g. "d" for "/d")
+	Rounding []string `json:"rounding,omitempty"`
 }
 
-// GetDefaultParsers returns a list of default parsers including RFC3339, Unix Seconds and Aliases Parsers
+// GetDefaultParsers returns a list of default parsers including RFC3339, Unix Seconds and Aliases Parsers.
+// The compact date parser is opt-in (see GetAllParsers), so by default "20240101" is just unix seconds.
 func GetDefaultParsers() []Parser {
 	return []Parser{
 		NewBaseParser(),
@@ -52,6 +136,7 @@ func GetAllParsers() []Parser {
 		NewBaseParser(),
 		NewUnixMilliParser(),
 		NewUnixSecondsParser(),
+		NewCompactDateParser(),
 		NewAliasesParser(),
 	}
 }
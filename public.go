@@ -15,6 +15,11 @@ func ParseTime(s string, locArg ...*time.Location) (time.Time, error) {
 	return DefaultTimeParser.Parse(s, locArg...)
 }
 
+// Compile compiles the given string into an Expression using the DefaultTimeParser
+func Compile(s string) (*Expression, error) {
+	return DefaultTimeParser.Compile(s)
+}
+
 // SetIntervalArithmetics enables intervalArithmetics mode on the DefaultTimeParser
 func SetIntervalArithmetics() {
 	globalTimeParserOptions = append(globalTimeParserOptions, WithIntervalArithmetics())
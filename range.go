@@ -0,0 +1,43 @@
+package epoch
+
+import (
+	"time"
+)
+
+// Range is a half-open period of time [Start, End)
+type Range struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// NewRange returns a range between start and end
+func NewRange(start, end time.Time) Range {
+	return Range{Start: start, End: end}
+}
+
+// IsEmpty returns true if the range contains no instants (End is not after Start)
+func (r Range) IsEmpty() bool {
+	return !r.End.After(r.Start)
+}
+
+// Duration returns the length of the range (zero for empty ranges)
+func (r Range) Duration() time.Duration {
+	if r.IsEmpty() {
+		return 0
+	}
+	return r.End.Sub(r.Start)
+}
+
+// Contains checks if t is within the range (Start inclusive, End exclusive)
+func (r Range) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && t.Before(r.End)
+}
+
+// In returns the same range with both ends in the given location
+func (r Range) In(loc *time.Location) Range {
+	return Range{Start: r.Start.In(loc), End: r.End.In(loc)}
+}
+
+func (r Range) String() string {
+	return r.Start.Format(time.RFC3339Nano) + "/" + r.End.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,156 @@
+package epoch
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// RangeSet is a set of instants given by ranges.
+// Ranges of a set are normalized: sorted, non-empty, and neither overlapping nor adjacent.
+// Sets are immutable, operations return new sets.
+type RangeSet struct {
+	ranges []Range
+}
+
+// NewRangeSet returns a set of the given ranges, merging overlapping and adjacent ones
+func NewRangeSet(ranges ...Range) RangeSet {
+	sorted := make([]Range, 0, len(ranges))
+	for _, r := range ranges {
+		if !r.IsEmpty() {
+			sorted = append(sorted, r)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+
+	var normalized []Range
+	for _, r := range sorted {
+		if n := len(normalized); n > 0 && !r.Start.After(normalized[n-1].End) {
+			if r.End.After(normalized[n-1].End) {
+				normalized[n-1].End = r.End
+			}
+			continue
+		}
+		normalized = append(normalized, r)
+	}
+	return RangeSet{ranges: normalized}
+}
+
+// Ranges returns normalized ranges of the set
+func (s RangeSet) Ranges() []Range {
+	return append([]Range(nil), s.ranges...)
+}
+
+// IsEmpty returns true if the set contains no instants
+func (s RangeSet) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+// Duration returns the total coverage of the set
+func (s RangeSet) Duration() time.Duration {
+	var d time.Duration
+	for _, r := range s.ranges {
+		d += r.Duration()
+	}
+	return d
+}
+
+// Contains checks if t is within one of ranges of the set
+func (s RangeSet) Contains(t time.Time) bool {
+	// the first range ending after t is the only one that can contain it
+	i := sort.Search(len(s.ranges), func(i int) bool {
+		return s.ranges[i].End.After(t)
+	})
+	return i < len(s.ranges) && s.ranges[i].Contains(t)
+}
+
+// Union returns instants that are in either set
+func (s RangeSet) Union(other RangeSet) RangeSet {
+	return NewRangeSet(append(s.Ranges(), other.ranges...)...)
+}
+
+// Intersect returns instants that are in both sets
+func (s RangeSet) Intersect(other RangeSet) RangeSet {
+	var ranges []Range
+	a, b := s.ranges, other.ranges
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		r := Range{Start: laterTime(a[i].Start, b[j].Start), End: earlierTime(a[i].End, b[j].End)}
+		if !r.IsEmpty() {
+			ranges = append(ranges, r)
+		}
+		// the range ending first can't intersect anything else
+		if a[i].End.Before(b[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return RangeSet{ranges: ranges}
+}
+
+// Subtract returns instants of the set that are not in the other set
+func (s RangeSet) Subtract(other RangeSet) RangeSet {
+	var ranges []Range
+	b := other.ranges
+	j := 0
+	for _, r := range s.ranges {
+		// skip ranges of the other set ending before r
+		for j < len(b) && !b[j].End.After(r.Start) {
+			j++
+		}
+		for k := j; k < len(b) && b[k].Start.Before(r.End); k++ {
+			if b[k].Start.After(r.Start) {
+				ranges = append(ranges, Range{Start: r.Start, End: b[k].Start})
+			}
+			if b[k].End.After(r.Start) {
+				r.Start = b[k].End
+			}
+		}
+		if !r.IsEmpty() {
+			ranges = append(ranges, r)
+		}
+	}
+	return RangeSet{ranges: ranges}
+}
+
+// Gaps returns parts of the given range not covered by the set
+func (s RangeSet) Gaps(within Range) RangeSet {
+	return NewRangeSet(within).Subtract(s)
+}
+
+// Equal checks if both sets contain the same instants
+func (s RangeSet) Equal(other RangeSet) bool {
+	if len(s.ranges) != len(other.ranges) {
+		return false
+	}
+	for i, r := range s.ranges {
+		if !r.Start.Equal(other.ranges[i].Start) || !r.End.Equal(other.ranges[i].End) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s RangeSet) String() string {
+	parts := make([]string, len(s.ranges))
+	for i, r := range s.ranges {
+		parts[i] = r.String()
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+func laterTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlierTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,132 @@
+package epoch_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing/quick"
+	"time"
+
+	"github.com/aahainc/epoch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var rangeSetOrigin = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// minutes returns the range between the given minutes since rangeSetOrigin
+func minutes(start, end int) epoch.Range {
+	return epoch.NewRange(rangeSetOrigin.Add(time.Duration(start)*time.Minute), rangeSetOrigin.Add(time.Duration(end)*time.Minute))
+}
+
+// randomRangeSet is a set of a few ranges within the first 100 minutes, so they often overlap or touch
+type randomRangeSet struct {
+	epoch.RangeSet
+}
+
+func (randomRangeSet) Generate(r *rand.Rand, _ int) reflect.Value {
+	ranges := make([]epoch.Range, r.Intn(6))
+	for i := range ranges {
+		start := r.Intn(100)
+		ranges[i] = minutes(start, start+r.Intn(20)-2) // some of ranges are empty
+	}
+	return reflect.ValueOf(randomRangeSet{epoch.NewRangeSet(ranges...)})
+}
+
+// samples are instants to compare sets by membership, including ends of ranges and instants between them
+func samples() []time.Time {
+	var times []time.Time
+	for m := -1; m <= 121; m++ {
+		t := rangeSetOrigin.Add(time.Duration(m) * time.Minute)
+		times = append(times, t, t.Add(30*time.Second))
+	}
+	return times
+}
+
+var _ = Describe("RangeSet", func() {
+	config := &quick.Config{MaxCount: 500, Rand: rand.New(rand.NewSource(1))}
+	check := func(f interface{}) {
+		Expect(quick.Check(f, config)).To(Succeed())
+	}
+
+	It("normalizes ranges", func() {
+		s := epoch.NewRangeSet(minutes(20, 30), minutes(0, 10), minutes(5, 15), minutes(15, 18), minutes(40, 40))
+		Expect(s.Ranges()).To(Equal([]epoch.Range{minutes(0, 18), minutes(20, 30)}))
+		Expect(s.Duration()).To(Equal(28 * time.Minute))
+	})
+
+	It("computes set operations", func() {
+		a := epoch.NewRangeSet(minutes(0, 10), minutes(20, 30))
+		b := epoch.NewRangeSet(minutes(5, 25))
+		Expect(a.Union(b).Ranges()).To(Equal([]epoch.Range{minutes(0, 30)}))
+		Expect(a.Intersect(b).Ranges()).To(Equal([]epoch.Range{minutes(5, 10), minutes(20, 25)}))
+		Expect(a.Subtract(b).Ranges()).To(Equal([]epoch.Range{minutes(0, 5), minutes(25, 30)}))
+		Expect(a.Gaps(minutes(-5, 40)).Ranges()).To(Equal([]epoch.Range{minutes(-5, 0), minutes(10, 20), minutes(30, 40)}))
+		Expect(a.Contains(minutes(10, 11).Start)).To(BeFalse())
+		Expect(a.Contains(minutes(29, 30).Start)).To(BeTrue())
+	})
+
+	Context("properties", func() {
+		It("keeps ranges normalized", func() {
+			check(func(a, b randomRangeSet) bool {
+				for _, s := range []epoch.RangeSet{a.RangeSet, a.Union(b.RangeSet), a.Intersect(b.RangeSet), a.Subtract(b.RangeSet)} {
+					ranges := s.Ranges()
+					for i, r := range ranges {
+						if r.IsEmpty() || (i > 0 && !r.Start.After(ranges[i-1].End)) {
+							return false
+						}
+					}
+					if !epoch.NewRangeSet(ranges...).Equal(s) {
+						return false
+					}
+				}
+				return true
+			})
+		})
+
+		It("agrees with membership", func() {
+			check(func(a, b randomRangeSet) bool {
+				union, intersection, difference := a.Union(b.RangeSet), a.Intersect(b.RangeSet), a.Subtract(b.RangeSet)
+				for _, t := range samples() {
+					inA, inB := a.Contains(t), b.Contains(t)
+					if union.Contains(t) != (inA || inB) || intersection.Contains(t) != (inA && inB) || difference.Contains(t) != (inA && !inB) {
+						return false
+					}
+				}
+				return true
+			})
+		})
+
+		It("is commutative and associative", func() {
+			check(func(a, b, c randomRangeSet) bool {
+				return a.Union(b.RangeSet).Equal(b.Union(a.RangeSet)) &&
+					a.Intersect(b.RangeSet).Equal(b.Intersect(a.RangeSet)) &&
+					a.Union(b.RangeSet).Union(c.RangeSet).Equal(a.Union(b.Union(c.RangeSet))) &&
+					a.Intersect(b.RangeSet).Intersect(c.RangeSet).Equal(a.Intersect(b.Intersect(c.RangeSet)))
+			})
+		})
+
+		It("is distributive", func() {
+			check(func(a, b, c randomRangeSet) bool {
+				return a.Intersect(b.Union(c.RangeSet)).Equal(a.Intersect(b.RangeSet).Union(a.Intersect(c.RangeSet))) &&
+					a.Subtract(b.Union(c.RangeSet)).Equal(a.Subtract(b.RangeSet).Subtract(c.RangeSet))
+			})
+		})
+
+		It("adds up coverage", func() {
+			check(func(a, b randomRangeSet) bool {
+				return a.Union(b.RangeSet).Duration() == a.Duration()+b.Duration()-a.Intersect(b.RangeSet).Duration() &&
+					a.Duration() == a.Subtract(b.RangeSet).Duration()+a.Intersect(b.RangeSet).Duration()
+			})
+		})
+
+		It("complements gaps", func() {
+			within := minutes(10, 90)
+			window := epoch.NewRangeSet(within)
+			check(func(a randomRangeSet) bool {
+				gaps := a.Gaps(within)
+				covered := a.Intersect(window)
+				return gaps.Intersect(covered).IsEmpty() && gaps.Union(covered).Equal(window)
+			})
+		})
+	})
+})
@@ -0,0 +1,198 @@
+package epoch
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidRetention = fmt.Errorf("invalid retention policy")
+)
+
+// RetentionTier keeps data at the resolution for the given period, e.g. 1h rollups for 90d
+type RetentionTier struct {
+	// Resolution is the bucket data is rolled into, nil for raw data
+	Resolution *Interval `json:"resolution"`
+	// Keep is the maximal age of data in the tier
+	Keep *Interval `json:"keep"`
+}
+
+// IsRaw returns true if data of the tier isn't rolled up
+func (t RetentionTier) IsRaw() bool {
+	return t.Resolution.IsNil()
+}
+
+func (t RetentionTier) String() string {
+	if t.IsRaw() {
+		return "raw:" + t.Keep.String()
+	}
+	return t.Resolution.String() + ":" + t.Keep.String()
+}
+
+// RetentionDecision tells what happens to data at some time
+type RetentionDecision struct {
+	// Tier is the index of the tier, -1 if the data is to be deleted
+	Tier int `json:"tier"`
+	// Delete is true if the data is older than all the tiers keep
+	Delete bool `json:"delete"`
+	// Bucket is the bucket of the tier's resolution the data rolls into (zero for raw and deleted data)
+	Bucket Range `json:"bucket"`
+}
+
+// RetentionOption configures a RetentionPolicy
+type RetentionOption func(*RetentionPolicy)
+
+// WithRetentionClock sets the clock "now" is taken from (the default clock by default)
+func WithRetentionClock(c Clock) RetentionOption {
+	return func(p *RetentionPolicy) {
+		p.clock = c
+	}
+}
+
+// WithRetentionLocation sets the location buckets and ages are computed in (the location of the given time by default)
+func WithRetentionLocation(loc *time.Location) RetentionOption {
+	return func(p *RetentionPolicy) {
+		p.location = loc
+	}
+}
+
+// WithRetentionUnits sets the registry used to parse, add and align intervals (built-in units by default)
+func WithRetentionUnits(units *UnitRegistry) RetentionOption {
+	return func(p *RetentionPolicy) {
+		p.units = units
+	}
+}
+
+// RetentionPolicy tells for how long data is kept and at what resolution,
+// e.g. raw data for 7 days, 1h rollups for 90 days and 1d rollups for 2 years.
+// Rollup buckets are aligned the same way as TruncateToInterval.
+type RetentionPolicy struct {
+	tiers     []RetentionTier
+	schedules []*IntervalSchedule
+	clock     Clock
+	location  *time.Location
+	units     *UnitRegistry
+}
+
+// NewRetentionPolicy returns a policy of the tiers.
+// Tiers must be ordered by both resolution and keep, only the first one can be raw.
+func NewRetentionPolicy(tiers []RetentionTier, options ...RetentionOption) (*RetentionPolicy, error) {
+	p := newRetentionPolicy(options)
+	p.tiers = append([]RetentionTier(nil), tiers...)
+
+	if len(p.tiers) == 0 {
+		return nil, fmt.Errorf("%w: no tiers", ErrInvalidRetention)
+	}
+
+	p.schedules = make([]*IntervalSchedule, len(p.tiers))
+	for i, tier := range p.tiers {
+		if tier.Keep.IsNil() || !p.units.longer(tier.Keep, nil) {
+			return nil, fmt.Errorf("%w: tier %d must keep data for a positive interval", ErrInvalidRetention, i)
+		}
+		if i > 0 {
+			prev := p.tiers[i-1]
+			if tier.IsRaw() {
+				return nil, fmt.Errorf("%w: only the first tier can be raw", ErrInvalidRetention)
+			}
+			if !p.units.longer(tier.Keep, prev.Keep) {
+				return nil, fmt.Errorf("%w: tier %s must keep data longer than %s", ErrInvalidRetention, tier, prev)
+			}
+			if !prev.IsRaw() && !p.units.longer(tier.Resolution, prev.Resolution) {
+				return nil, fmt.Errorf("%w: tier %s must have a coarser resolution than %s", ErrInvalidRetention, tier, prev)
+			}
+		}
+
+		if !tier.IsRaw() {
+			var err error
+			p.schedules[i], err = NewIntervalSchedule(tier.Resolution,
+				WithScheduleLocation(p.location), WithScheduleUnits(p.units))
+			if err != nil {
+				return nil, fmt.Errorf("%w: resolution %s can't be aligned", ErrInvalidRetention, tier.Resolution)
+			}
+		}
+	}
+
+	return p, nil
+}
+
+func newRetentionPolicy(options []RetentionOption) *RetentionPolicy {
+	p := &RetentionPolicy{
+		clock: NewDefaultClock(),
+		units: defaultUnitRegistry,
+	}
+	for _, opt := range options {
+		opt(p)
+	}
+	return p
+}
+
+// ParseRetentionPolicy parses a policy from a compact string of resolution:keep tiers, e.g. "raw:7d,1h:90d,1d:2y"
+func ParseRetentionPolicy(s string, options ...RetentionOption) (*RetentionPolicy, error) {
+	units := newRetentionPolicy(options).units
+
+	var tiers []RetentionTier
+	for _, part := range strings.Split(s, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), ":", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%w: malformed tier %q", ErrInvalidRetention, part)
+		}
+
+		var tier RetentionTier
+		var err error
+		if kv[0] != "raw" {
+			if tier.Resolution, err = units.ParseInterval(kv[0]); err != nil {
+				return nil, fmt.Errorf("%w: tier %q: %s", ErrInvalidRetention, part, err)
+			}
+		}
+		if tier.Keep, err = units.ParseInterval(kv[1]); err != nil {
+			return nil, fmt.Errorf("%w: tier %q: %s", ErrInvalidRetention, part, err)
+		}
+		tiers = append(tiers, tier)
+	}
+
+	return NewRetentionPolicy(tiers, options...)
+}
+
+// Tiers returns tiers of the policy
+func (p *RetentionPolicy) Tiers() []RetentionTier {
+	return append([]RetentionTier(nil), p.tiers...)
+}
+
+// Evaluate tells which tier data at t belongs to now (see WithRetentionClock),
+// whether it's to be deleted and which bucket it rolls into
+func (p *RetentionPolicy) Evaluate(t time.Time) RetentionDecision {
+	now := p.clock.Now()
+	if p.location != nil {
+		now = now.In(p.location)
+	}
+
+	for i, tier := range p.tiers {
+		// data is kept while it's not older than keep
+		if t.Before(p.units.AddInterval(now, &Interval{Value: -tier.Keep.Value, Unit: tier.Keep.Unit})) {
+			continue
+		}
+
+		decision := RetentionDecision{Tier: i}
+		if s := p.schedules[i]; s != nil {
+			start := s.Truncate(t)
+			decision.Bucket = Range{Start: start, End: s.Next(start)}
+		}
+		return decision
+	}
+
+	return RetentionDecision{Tier: -1, Delete: true}
+}
+
+// ShouldDelete returns true if data at t is older than all the tiers keep
+func (p *RetentionPolicy) ShouldDelete(t time.Time) bool {
+	return p.Evaluate(t).Delete
+}
+
+func (p *RetentionPolicy) String() string {
+	parts := make([]string, len(p.tiers))
+	for i, tier := range p.tiers {
+		parts[i] = tier.String()
+	}
+	return strings.Join(parts, ",")
+}
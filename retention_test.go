@@ -0,0 +1,86 @@
+package epoch_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aahainc/epoch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RetentionPolicy", func() {
+	now := time.Date(2024, time.June, 15, 12, 30, 0, 0, time.UTC)
+	clock := epoch.NewFakeClock(now)
+
+	var policy *epoch.RetentionPolicy
+	BeforeEach(func() {
+		var err error
+		policy, err = epoch.ParseRetentionPolicy("raw:7d, 1h:90d, 1d:2y", epoch.WithRetentionClock(clock))
+		Expect(err).To(Succeed())
+	})
+
+	It("parses tiers", func() {
+		tiers := policy.Tiers()
+		Expect(tiers).To(HaveLen(3))
+		Expect(tiers[0].IsRaw()).To(BeTrue())
+		Expect(tiers[1].Resolution).To(Equal(epoch.MustParseInterval("1h")))
+		Expect(tiers[2].Keep).To(Equal(epoch.MustParseInterval("2y")))
+		Expect(policy.String()).To(Equal("raw:7d,1h:90d,1d:2y"))
+	})
+
+	It("keeps recent data raw", func() {
+		d := policy.Evaluate(now.Add(-6 * 24 * time.Hour))
+		Expect(d.Tier).To(Equal(0))
+		Expect(d.Delete).To(BeFalse())
+		Expect(d.Bucket).To(BeZero())
+	})
+
+	It("rolls data into buckets of the tier", func() {
+		t := time.Date(2024, time.May, 1, 10, 42, 0, 0, time.UTC)
+		d := policy.Evaluate(t)
+		Expect(d.Tier).To(Equal(1))
+		Expect(d.Bucket.String()).To(Equal("2024-05-01T10:00:00Z/2024-05-01T11:00:00Z"))
+
+		d = policy.Evaluate(time.Date(2023, time.January, 1, 10, 42, 0, 0, time.UTC))
+		Expect(d.Tier).To(Equal(2))
+		Expect(d.Bucket.String()).To(Equal("2023-01-01T00:00:00Z/2023-01-02T00:00:00Z"))
+	})
+
+	It("deletes data older than all the tiers", func() {
+		Expect(policy.ShouldDelete(time.Date(2022, time.June, 15, 12, 29, 0, 0, time.UTC))).To(BeTrue())
+		Expect(policy.ShouldDelete(time.Date(2022, time.June, 15, 12, 30, 0, 0, time.UTC))).To(BeFalse())
+		Expect(policy.Evaluate(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)).Tier).To(Equal(-1))
+	})
+
+	It("takes now from the clock", func() {
+		t := now.Add(-6 * 24 * time.Hour)
+		c := epoch.NewFakeClock(now)
+		p, err := epoch.NewRetentionPolicy(policy.Tiers(), epoch.WithRetentionClock(c))
+		Expect(err).To(Succeed())
+		Expect(p.Evaluate(t).Tier).To(Equal(0))
+		c.Advance(48 * time.Hour)
+		Expect(p.Evaluate(t).Tier).To(Equal(1))
+	})
+
+	It("aligns buckets in a location", func() {
+		berlin, _ := time.LoadLocation("Europe/Berlin")
+		p, err := epoch.ParseRetentionPolicy("1d:30d", epoch.WithRetentionClock(clock), epoch.WithRetentionLocation(berlin))
+		Expect(err).To(Succeed())
+		d := p.Evaluate(time.Date(2024, time.June, 1, 23, 0, 0, 0, time.UTC))
+		Expect(d.Bucket.String()).To(Equal("2024-06-02T00:00:00+02:00/2024-06-03T00:00:00+02:00"))
+	})
+
+	DescribeTable("invalid policies", func(s string) {
+		_, err := epoch.ParseRetentionPolicy(s)
+		Expect(errors.Is(err, epoch.ErrInvalidRetention)).To(BeTrue(), s)
+	},
+		Entry("malformed tier", "raw"),
+		Entry("invalid interval", "raw:7x"),
+		Entry("keep isn't increasing", "raw:7d,1h:5d"),
+		Entry("resolution isn't increasing", "1d:7d,1h:90d"),
+		Entry("raw tier after rollups", "1h:7d,raw:90d"),
+		Entry("non-positive keep", "raw:0d"),
+		Entry("resolution can't be aligned", "raw:7d,1.5mo:2y"),
+	)
+})
@@ -0,0 +1,732 @@
+package epoch
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidRRule = fmt.Errorf("invalid recurrence rule")
+)
+
+// maxRRuleGapYears stops the iteration of rules that never (or no longer) produce occurrences,
+// e.g. FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30: the Gregorian calendar repeats every 400 years,
+// so a rule without occurrences within them has none at all
+const maxRRuleGapYears = 400
+
+// rruleFrequencies maps FREQ values onto units
+var rruleFrequencies = map[string]Unit{
+	"SECONDLY": UnitSecond,
+	"MINUTELY": UnitMinute,
+	"HOURLY":   UnitHour,
+	"DAILY":    UnitDay,
+	"WEEKLY":   UnitWeek,
+	"MONTHLY":  UnitMonth,
+	"YEARLY":   UnitYear,
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// RRuleWeekday is a BYDAY value, e.g. "2TU" (the second Tuesday) or "-1FR" (the last Friday).
+// N is zero for every weekday of the period.
+type RRuleWeekday struct {
+	Weekday time.Weekday `json:"weekday"`
+	N       int          `json:"n,omitempty"`
+}
+
+// RRule is a recurrence rule of RFC 5545 (section 3.3.10), e.g. "FREQ=MONTHLY;BYDAY=2TU".
+// FREQ and INTERVAL are mapped onto a Unit and a number of units (see Step).
+type RRule struct {
+	// Freq is the unit of the frequency: UnitSecond (SECONDLY) ... UnitYear (YEARLY)
+	Freq Unit `json:"freq"`
+	// Interval is the number of Freq units between periods (1 by default)
+	Interval int `json:"interval"`
+	// Count limits the number of occurrences (0 means no limit)
+	Count int `json:"count,omitempty"`
+	// Until is the last possible occurrence (inclusive), zero means no limit
+	Until     time.Time    `json:"until,omitempty"`
+	WeekStart time.Weekday `json:"week_start"`
+
+	BySecond   []int          `json:"by_second,omitempty"`
+	ByMinute   []int          `json:"by_minute,omitempty"`
+	ByHour     []int          `json:"by_hour,omitempty"`
+	ByDay      []RRuleWeekday `json:"by_day,omitempty"`
+	ByMonthDay []int          `json:"by_month_day,omitempty"`
+	ByYearDay  []int          `json:"by_year_day,omitempty"`
+	ByWeekNo   []int          `json:"by_week_no,omitempty"`
+	ByMonth    []int          `json:"by_month,omitempty"`
+	BySetPos   []int          `json:"by_set_pos,omitempty"`
+}
+
+// Step returns the interval between periods of the rule, e.g. 2w for FREQ=WEEKLY;INTERVAL=2
+func (r RRule) Step() *Interval {
+	return &Interval{Value: float64(r.Interval), Unit: r.Freq}
+}
+
+// ParseRRule parses the value of an RRULE property, e.g. "FREQ=MONTHLY;BYDAY=2TU;COUNT=10".
+// The "RRULE:" prefix is allowed. Floating and date UNTIL values are parsed in the given location (UTC if nil).
+func ParseRRule(s string, loc *time.Location) (RRule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	r := RRule{Interval: 1, WeekStart: time.Monday}
+
+	for _, part := range strings.Split(s, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return RRule{}, fmt.Errorf("%w: malformed part %q", ErrInvalidRRule, part)
+		}
+		key, value := strings.ToUpper(kv[0]), strings.ToUpper(kv[1])
+
+		var err error
+		switch key {
+		case "FREQ":
+			var ok bool
+			if r.Freq, ok = rruleFrequencies[value]; !ok {
+				err = fmt.Errorf("unknown frequency")
+			}
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+			if err == nil && r.Interval < 1 {
+				err = fmt.Errorf("must be positive")
+			}
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+			if err == nil && r.Count < 1 {
+				err = fmt.Errorf("must be positive")
+			}
+		case "UNTIL":
+			var isDate bool
+			r.Until, isDate, err = parseICSDateTime(value, nil, loc)
+			if isDate {
+				// a date includes the whole day
+				r.Until = r.Until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+		case "WKST":
+			var ok bool
+			if r.WeekStart, ok = rruleWeekdays[value]; !ok {
+				err = fmt.Errorf("unknown weekday")
+			}
+		case "BYSECOND":
+			r.BySecond, err = parseRRuleInts(value, 0, 60, false)
+		case "BYMINUTE":
+			r.ByMinute, err = parseRRuleInts(value, 0, 59, false)
+		case "BYHOUR":
+			r.ByHour, err = parseRRuleInts(value, 0, 23, false)
+		case "BYDAY":
+			r.ByDay, err = parseRRuleWeekdays(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseRRuleInts(value, 1, 31, true)
+		case "BYYEARDAY":
+			r.ByYearDay, err = parseRRuleInts(value, 1, 366, true)
+		case "BYWEEKNO":
+			r.ByWeekNo, err = parseRRuleInts(value, 1, 53, true)
+		case "BYMONTH":
+			r.ByMonth, err = parseRRuleInts(value, 1, 12, false)
+		case "BYSETPOS":
+			r.BySetPos, err = parseRRuleInts(value, 1, 366, true)
+		default:
+			err = fmt.Errorf("unknown part")
+		}
+		if err != nil {
+			return RRule{}, fmt.Errorf("%w: %s=%s: %s", ErrInvalidRRule, key, value, err)
+		}
+	}
+
+	if r.Freq.IsNil() {
+		return RRule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidRRule)
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return RRule{}, fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRRule)
+	}
+	return r, nil
+}
+
+// parseRRuleInts parses a list of numbers in [min, max] (or in [-max, -min] if negative values are allowed)
+func parseRRuleInts(value string, min, max int, negative bool) ([]int, error) {
+	var values []int
+	for _, s := range strings.Split(value, ",") {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, err
+		}
+		abs := v
+		if negative && v < 0 {
+			abs = -v
+		}
+		if abs < min || abs > max {
+			return nil, fmt.Errorf("%d is out of range", v)
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func parseRRuleWeekdays(value string) ([]RRuleWeekday, error) {
+	var days []RRuleWeekday
+	for _, s := range strings.Split(value, ",") {
+		if len(s) < 2 {
+			return nil, fmt.Errorf("invalid weekday %q", s)
+		}
+		w, ok := rruleWeekdays[s[len(s)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", s)
+		}
+		day := RRuleWeekday{Weekday: w}
+		if n := s[:len(s)-2]; n != "" {
+			var err error
+			day.N, err = strconv.Atoi(n)
+			if err != nil || day.N == 0 || day.N < -53 || day.N > 53 {
+				return nil, fmt.Errorf("invalid weekday %q", s)
+			}
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// Recurrence is a set of occurrences given by DTSTART, an RRULE and EXDATEs.
+// Occurrences are computed on the wall clock of DTSTART's location: times skipped by DST are shifted forward
+// (as RFC 5545 requires), times repeated by DST occur once.
+type Recurrence struct {
+	// Start is DTSTART, the first possible occurrence
+	Start time.Time `json:"start"`
+	// Duration is the length of each occurrence (given by DTEND or DURATION), it's used by Ranges
+	Duration time.Duration `json:"duration"`
+	Rule     RRule         `json:"rule"`
+	// ExDates are excluded occurrences (they are still counted by COUNT)
+	ExDates []time.Time `json:"exdates,omitempty"`
+}
+
+// NewRecurrence returns a recurrence of the rule starting at the given time
+func NewRecurrence(start time.Time, rule RRule) *Recurrence {
+	return &Recurrence{Start: start, Rule: rule}
+}
+
+// ParseRecurrence parses iCalendar content lines describing a recurrence, e.g.
+//
+//	DTSTART;TZID=Europe/Berlin:20240109T020000
+//	DTEND;TZID=Europe/Berlin:20240109T040000
+//	RRULE:FREQ=MONTHLY;BYDAY=2TU
+//	EXDATE;TZID=Europe/Berlin:20240213T020000
+//
+// DURATION can be given instead of DTEND. Floating times are parsed in the given location (UTC if nil).
+// EXDATEs must have the same value type as DTSTART (both DATE or both DATE-TIME).
+func ParseRecurrence(data string, loc *time.Location) (*Recurrence, error) {
+	properties, err := parseICSProperties([]byte(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var rec Recurrence
+	var start, end *icsProperty
+	var rule string
+	var exDateValues []bool
+	for i := range properties {
+		p := properties[i]
+		switch p.Name {
+		case "DTSTART":
+			start = &properties[i]
+		case "DTEND":
+			end = &properties[i]
+		case "DURATION":
+			if rec.Duration, err = parseICSDuration(p.Value); err != nil {
+				return nil, err
+			}
+		case "RRULE":
+			if rule != "" {
+				return nil, fmt.Errorf("%w: several RRULEs are not supported", ErrInvalidRRule)
+			}
+			rule = p.Value
+		case "EXDATE":
+			for _, value := range strings.Split(p.Value, ",") {
+				t, isDate, err := parseICSDateTime(value, p.Params, loc)
+				if err != nil {
+					return nil, err
+				}
+				rec.ExDates = append(rec.ExDates, t)
+				exDateValues = append(exDateValues, isDate)
+			}
+		}
+	}
+
+	if start == nil || rule == "" {
+		return nil, fmt.Errorf("%w: DTSTART and RRULE are required", ErrInvalidRRule)
+	}
+	var startIsDate bool
+	if rec.Start, startIsDate, err = parseICSDateTime(start.Value, start.Params, loc); err != nil {
+		return nil, err
+	}
+	// EXDATEs match occurrences by the instant, so a DATE can't exclude a DATE-TIME occurrence and vice versa
+	for _, isDate := range exDateValues {
+		if isDate != startIsDate {
+			return nil, fmt.Errorf("%w: EXDATE and DTSTART must have the same value type (DATE or DATE-TIME)", ErrInvalidRRule)
+		}
+	}
+	if end != nil {
+		t, _, err := parseICSDateTime(end.Value, end.Params, loc)
+		if err != nil {
+			return nil, err
+		}
+		rec.Duration = t.Sub(rec.Start)
+	}
+	if rec.Rule, err = ParseRRule(rule, rec.Start.Location()); err != nil {
+		return nil, err
+	}
+
+	return &rec, nil
+}
+
+// parseICSDuration parses DURATION values (RFC 5545, section 3.3.6), e.g. "PT1H30M" or "P1D"
+func parseICSDuration(value string) (time.Duration, error) {
+	invalid := fmt.Errorf("%w: invalid duration %q", ErrInvalidCalendar, value)
+
+	s, sign := value, time.Duration(1)
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		if s[0] == '-' {
+			sign = -1
+		}
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, invalid
+	}
+
+	var d time.Duration
+	inTime := false
+	n := 0
+	for _, c := range s[1:] {
+		switch {
+		case c >= '0' && c <= '9':
+			n = n*10 + int(c-'0')
+			continue
+		case c == 'T':
+			inTime = true
+			continue
+		case c == 'W' && !inTime:
+			d += time.Duration(n) * 7 * 24 * time.Hour
+		case c == 'D' && !inTime:
+			d += time.Duration(n) * 24 * time.Hour
+		case c == 'H' && inTime:
+			d += time.Duration(n) * time.Hour
+		case c == 'M' && inTime:
+			d += time.Duration(n) * time.Minute
+		case c == 'S' && inTime:
+			d += time.Duration(n) * time.Second
+		default:
+			return 0, invalid
+		}
+		n = 0
+	}
+	return sign * d, nil
+}
+
+// Iter returns an iterator over occurrences in chronological order
+func (rec *Recurrence) Iter() *RecurrenceIterator {
+	it := &RecurrenceIterator{
+		rec:     rec,
+		wall:    wallClock(rec.Start),
+		exclude: make(map[int64]bool, len(rec.ExDates)),
+	}
+	it.horizon = it.wall.AddDate(maxRRuleGapYears, 0, 0)
+	for _, t := range rec.ExDates {
+		it.exclude[t.UnixNano()] = true
+	}
+	return it
+}
+
+// Between returns all occurrences within the range (Start inclusive, End exclusive)
+func (rec *Recurrence) Between(r Range) []time.Time {
+	var occurrences []time.Time
+	it := rec.Iter()
+	it.skipTo(r.Start)
+	for t, ok := it.Next(); ok && t.Before(r.End); t, ok = it.Next() {
+		if r.Contains(t) {
+			occurrences = append(occurrences, t)
+		}
+	}
+	return occurrences
+}
+
+// Ranges expands occurrences into ranges of the recurrence's Duration (e.g. maintenance windows)
+// that overlap the given range
+func (rec *Recurrence) Ranges(within Range) []Range {
+	var ranges []Range
+	it := rec.Iter()
+	if rec.Duration > 0 {
+		it.skipTo(within.Start.Add(-rec.Duration))
+	} else {
+		it.skipTo(within.Start)
+	}
+	for t, ok := it.Next(); ok && t.Before(within.End); t, ok = it.Next() {
+		r := Range{Start: t, End: t.Add(rec.Duration)}
+		if r.End.After(within.Start) || (rec.Duration == 0 && within.Contains(t)) {
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges
+}
+
+// RecurrenceIterator yields occurrences of a Recurrence one by one
+type RecurrenceIterator struct {
+	rec     *Recurrence
+	wall    time.Time
+	exclude map[int64]bool
+
+	period  int
+	pending []time.Time
+	emitted int
+	last    time.Time
+	horizon time.Time
+	done    bool
+}
+
+// Next returns the next occurrence; false is returned when there are no more occurrences
+func (it *RecurrenceIterator) Next() (time.Time, bool) {
+	r := &it.rec.Rule
+	for !it.done {
+		if len(it.pending) == 0 {
+			p := it.periodStart(it.period)
+			if p.After(it.horizon) {
+				it.done = true
+				break
+			}
+			if it.subDaily() && !it.matchDay(TruncateToDay(p), p.Year()) {
+				// skip the rest of periods of the day at once
+				it.period = it.firstPeriodAfter(TruncateToDay(p).AddDate(0, 0, 1))
+				continue
+			}
+			it.pending = it.expand(p)
+			it.period++
+			continue
+		}
+
+		wall := it.pending[0]
+		it.pending = it.pending[1:]
+		if wall.Year() > 9999 {
+			it.done = true
+			break
+		}
+
+		t := resolveWallClock(wall, it.rec.Start.Location())
+		// a time shifted out of a DST gap may coincide with the next one (e.g. HOURLY)
+		if t.Before(it.rec.Start) || (it.emitted > 0 && !t.After(it.last)) {
+			continue
+		}
+		if (!r.Until.IsZero() && t.After(r.Until)) || (r.Count > 0 && it.emitted >= r.Count) {
+			it.done = true
+			break
+		}
+
+		it.emitted++
+		it.last = t
+		it.horizon = wall.AddDate(maxRRuleGapYears, 0, 0)
+		if it.exclude[t.UnixNano()] {
+			continue
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// wallClock returns the wall clock of t as a time in UTC
+func wallClock(t time.Time) time.Time {
+	y, m, d := t.Date()
+	h, mi, s := t.Clock()
+	return time.Date(y, m, d, h, mi, s, t.Nanosecond(), time.UTC)
+}
+
+// resolveWallClock returns the instant of the wall clock in loc: nonexistent times are shifted forward
+// by the length of the gap, ambiguous ones are resolved to the earlier instant
+func resolveWallClock(wall time.Time, loc *time.Location) time.Time {
+	kind, instants := InspectLocalTime(wall, loc)
+	if kind == LocalTimeNonexistent {
+		// the wall clock read with the offset before the gap (RFC 5545, section 3.3.5) is the latest candidate
+		return instants[len(instants)-1]
+	}
+	return instants[0]
+}
+
+// periodStart returns the wall clock of the start of the k-th period
+func (it *RecurrenceIterator) periodStart(k int) time.Time {
+	r := &it.rec.Rule
+	w := it.wall
+	n := k * r.Interval
+
+	switch r.Freq {
+	case UnitYear:
+		return time.Date(w.Year()+n, time.January, 1, 0, 0, 0, 0, time.UTC)
+	case UnitMonth:
+		return time.Date(w.Year(), w.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	case UnitWeek:
+		offset := (int(w.Weekday()) - int(r.WeekStart) + 7) % 7
+		return time.Date(w.Year(), w.Month(), w.Day()-offset+7*n, 0, 0, 0, 0, time.UTC)
+	case UnitDay:
+		return time.Date(w.Year(), w.Month(), w.Day()+n, 0, 0, 0, 0, time.UTC)
+	default:
+		d, _ := defaultUnitRegistry.Duration(&Interval{Value: 1, Unit: r.Freq})
+		return w.Truncate(d).Add(time.Duration(n) * d)
+	}
+}
+
+// skipTo moves a new iterator to the period around t, so occurrences long before t aren't generated.
+// It's done only for periods of a fixed length (up to a week) and rules without COUNT,
+// which has to count occurrences from DTSTART.
+func (it *RecurrenceIterator) skipTo(t time.Time) {
+	r := &it.rec.Rule
+	if r.Count > 0 || it.emitted > 0 || !t.After(it.rec.Start) {
+		return
+	}
+
+	// a day earlier, so times shifted forward by DST are not skipped
+	target := wallClock(t.In(it.rec.Start.Location())).AddDate(0, 0, -1)
+	var k int
+	switch {
+	case it.subDaily():
+		d, _ := defaultUnitRegistry.Duration(&Interval{Value: 1, Unit: r.Freq})
+		k = int(target.Sub(it.wall.Truncate(d)) / (d * time.Duration(r.Interval)))
+	case r.Freq == UnitDay:
+		k = daysSince(it.periodStart(0), target) / r.Interval
+	case r.Freq == UnitWeek:
+		k = daysSince(it.periodStart(0), target) / (7 * r.Interval)
+	default:
+		return
+	}
+	if k > 0 {
+		it.period = k
+		it.horizon = it.periodStart(k).AddDate(maxRRuleGapYears, 0, 0)
+	}
+}
+
+// daysSince returns the number of whole days from the wall clock day "from" to t
+func daysSince(from, t time.Time) int {
+	return int(TruncateToDay(t).Sub(from).Hours() / 24)
+}
+
+// subDaily checks if periods of the rule are shorter than a day
+func (it *RecurrenceIterator) subDaily() bool {
+	f := it.rec.Rule.Freq
+	return f == UnitHour || f == UnitMinute || f == UnitSecond
+}
+
+// firstPeriodAfter returns the index of the first period starting at or after the wall clock t
+// (for sub-daily frequencies only)
+func (it *RecurrenceIterator) firstPeriodAfter(t time.Time) int {
+	r := &it.rec.Rule
+	d, _ := defaultUnitRegistry.Duration(&Interval{Value: 1, Unit: r.Freq})
+	step := d * time.Duration(r.Interval)
+	elapsed := t.Sub(it.wall.Truncate(d))
+	return int((elapsed + step - 1) / step)
+}
+
+// expand returns sorted wall clock candidates of the period starting at p
+func (it *RecurrenceIterator) expand(p time.Time) []time.Time {
+	r := &it.rec.Rule
+	w := it.wall
+
+	var days []time.Time
+	switch r.Freq {
+	case UnitYear:
+		if len(r.ByWeekNo) > 0 {
+			// weeks are numbered within the week-year, which may start in December and end in January
+			days = daysBetween(weekOneStart(p.Year(), r.WeekStart), weekOneStart(p.Year()+1, r.WeekStart))
+		} else {
+			days = daysBetween(p, p.AddDate(1, 0, 0))
+		}
+	case UnitMonth:
+		days = daysBetween(p, p.AddDate(0, 1, 0))
+	case UnitWeek:
+		days = daysBetween(p, p.AddDate(0, 0, 7))
+	default:
+		days = []time.Time{TruncateToDay(p)}
+	}
+
+	hours := rruleValues(r.ByHour, w.Hour(), p.Hour(), r.Freq == UnitHour || r.Freq == UnitMinute || r.Freq == UnitSecond)
+	minutes := rruleValues(r.ByMinute, w.Minute(), p.Minute(), r.Freq == UnitMinute || r.Freq == UnitSecond)
+	seconds := rruleValues(r.BySecond, w.Second(), p.Second(), r.Freq == UnitSecond)
+
+	var candidates []time.Time
+	for _, day := range days {
+		if !it.matchDay(day, p.Year()) {
+			continue
+		}
+		for _, h := range hours {
+			for _, mi := range minutes {
+				for _, s := range seconds {
+					candidates = append(candidates, time.Date(day.Year(), day.Month(), day.Day(), h, mi, s, 0, time.UTC))
+				}
+			}
+		}
+	}
+
+	sortTimes(candidates)
+	if len(r.BySetPos) > 0 {
+		candidates = selectSetPos(candidates, r.BySetPos)
+	}
+	return candidates
+}
+
+// rruleValues returns values of a BYxxx rule for a time field:
+// the field of the period is fixed (and limited by the rule) for frequencies shorter than the field,
+// otherwise the rule expands it (or the field of DTSTART is used)
+func rruleValues(by []int, start, period int, fixed bool) []int {
+	switch {
+	case fixed && len(by) > 0 && !containsInt(by, period):
+		return nil
+	case fixed:
+		return []int{period}
+	case len(by) > 0:
+		values := append([]int(nil), by...)
+		sort.Ints(values)
+		return values
+	default:
+		return []int{start}
+	}
+}
+
+func daysBetween(from, to time.Time) []time.Time {
+	var days []time.Time
+	for d := from; d.Before(to); d = d.AddDate(0, 0, 1) {
+		days = append(days, d)
+	}
+	return days
+}
+
+func selectSetPos(candidates []time.Time, positions []int) []time.Time {
+	var selected []time.Time
+	for _, pos := range positions {
+		i := pos - 1
+		if pos < 0 {
+			i = len(candidates) + pos
+		}
+		if i >= 0 && i < len(candidates) && !containsTime(selected, candidates[i]) {
+			selected = append(selected, candidates[i])
+		}
+	}
+	sortTimes(selected)
+	return selected
+}
+
+func containsTime(times []time.Time, t time.Time) bool {
+	for _, v := range times {
+		if v.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchDay applies day rules (BYMONTH, BYWEEKNO, BYYEARDAY, BYMONTHDAY, BYDAY) to the day
+// of the period in the given year (BYWEEKNO numbers weeks of that week-year)
+func (it *RecurrenceIterator) matchDay(day time.Time, year int) bool {
+	r := &it.rec.Rule
+	w := it.wall
+
+	if len(r.ByMonth) > 0 && !containsInt(r.ByMonth, int(day.Month())) {
+		return false
+	}
+	if len(r.ByWeekNo) > 0 && r.Freq == UnitYear && !matchOrdinal(r.ByWeekNo, weekNumber(day, year, r.WeekStart), weeksInYear(year, r.WeekStart)) {
+		return false
+	}
+	if len(r.ByYearDay) > 0 && !matchOrdinal(r.ByYearDay, day.YearDay(), time.Date(day.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()) {
+		return false
+	}
+	if len(r.ByMonthDay) > 0 && !matchOrdinal(r.ByMonthDay, day.Day(), daysIn(day)) {
+		return false
+	}
+	if len(r.ByDay) > 0 && !it.matchWeekday(day) {
+		return false
+	}
+
+	// without day rules, the day of DTSTART is repeated
+	switch r.Freq {
+	case UnitYear:
+		if len(r.ByWeekNo) == 0 && len(r.ByYearDay) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+			if len(r.ByMonth) == 0 && day.Month() != w.Month() {
+				return false
+			}
+			return day.Day() == w.Day()
+		}
+	case UnitMonth:
+		if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+			return day.Day() == w.Day()
+		}
+	case UnitWeek:
+		if len(r.ByDay) == 0 {
+			return day.Weekday() == w.Weekday()
+		}
+	}
+	return true
+}
+
+func (it *RecurrenceIterator) matchWeekday(day time.Time) bool {
+	r := &it.rec.Rule
+	for _, wd := range r.ByDay {
+		if wd.Weekday != day.Weekday() {
+			continue
+		}
+
+		switch {
+		case wd.N == 0:
+			return true
+		case r.Freq == UnitMonth || (r.Freq == UnitYear && len(r.ByMonth) > 0):
+			// the n-th weekday of the month
+			if (wd.N > 0 && (day.Day()-1)/7+1 == wd.N) || (wd.N < 0 && (daysIn(day)-day.Day())/7+1 == -wd.N) {
+				return true
+			}
+		case r.Freq == UnitYear && len(r.ByWeekNo) == 0:
+			// the n-th weekday of the year
+			yearDays := time.Date(day.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+			if (wd.N > 0 && (day.YearDay()-1)/7+1 == wd.N) || (wd.N < 0 && (yearDays-day.YearDay())/7+1 == -wd.N) {
+				return true
+			}
+		default:
+			// n is meaningless for other frequencies
+			return true
+		}
+	}
+	return false
+}
+
+// matchOrdinal checks if the 1-based value is in the list, negative values count from the end (-1 is the last one)
+func matchOrdinal(list []int, value, last int) bool {
+	for _, v := range list {
+		if v == value || (v < 0 && last+v+1 == value) {
+			return true
+		}
+	}
+	return false
+}
+
+// weekOneStart returns the first day of the first week of the year: the week containing at least 4 days of the year
+func weekOneStart(year int, weekStart time.Weekday) time.Time {
+	jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(jan1.Weekday()) - int(weekStart) + 7) % 7
+	if offset <= 3 {
+		return jan1.AddDate(0, 0, -offset)
+	}
+	return jan1.AddDate(0, 0, 7-offset)
+}
+
+// weekNumber returns the number of the week of the day within the week-year.
+// Days before the first week belong to week 0, days after the last one are numbered further.
+func weekNumber(day time.Time, year int, weekStart time.Weekday) int {
+	days := int(day.Sub(weekOneStart(year, weekStart)).Hours() / 24)
+	return floorDiv(days, 7) + 1
+}
+
+func weeksInYear(year int, weekStart time.Weekday) int {
+	return int(weekOneStart(year+1, weekStart).Sub(weekOneStart(year, weekStart)).Hours()/24) / 7
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,220 @@
+package epoch_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aahainc/epoch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RRule", func() {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	year := epoch.NewRange(date(2024, time.January, 1, 0, 0), date(2025, time.January, 1, 0, 0))
+
+	DescribeTable("occurrences", func(rule string, start, end time.Time, expected []time.Time) {
+		r, err := epoch.ParseRRule(rule, time.UTC)
+		Expect(err).To(Succeed())
+		Expect(epoch.NewRecurrence(start, r).Between(epoch.NewRange(start, end))).To(Equal(expected))
+	},
+		Entry("second Tuesday of a month", "FREQ=MONTHLY;BYDAY=2TU", date(2024, time.January, 9, 9, 0), date(2024, time.April, 1, 0, 0),
+			[]time.Time{date(2024, time.January, 9, 9, 0), date(2024, time.February, 13, 9, 0), date(2024, time.March, 12, 9, 0)}),
+		Entry("last Friday of a month", "FREQ=MONTHLY;BYDAY=-1FR", date(2024, time.January, 1, 9, 0), date(2024, time.April, 1, 0, 0),
+			[]time.Time{date(2024, time.January, 26, 9, 0), date(2024, time.February, 23, 9, 0), date(2024, time.March, 29, 9, 0)}),
+		Entry("last workday of a month", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", date(2024, time.January, 1, 18, 0), date(2024, time.April, 1, 0, 0),
+			[]time.Time{date(2024, time.January, 31, 18, 0), date(2024, time.February, 29, 18, 0), date(2024, time.March, 29, 18, 0)}),
+		Entry("daily with count", "FREQ=DAILY;COUNT=3", date(2024, time.January, 1, 9, 0), year.End,
+			[]time.Time{date(2024, time.January, 1, 9, 0), date(2024, time.January, 2, 9, 0), date(2024, time.January, 3, 9, 0)}),
+		Entry("every other week", "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;UNTIL=20240112T000000Z", date(2024, time.January, 1, 9, 0), year.End,
+			[]time.Time{date(2024, time.January, 2, 9, 0), date(2024, time.January, 4, 9, 0)}),
+		Entry("last day of a month", "FREQ=MONTHLY;BYMONTHDAY=-1", date(2024, time.January, 1, 0, 0), date(2024, time.April, 1, 0, 0),
+			[]time.Time{date(2024, time.January, 31, 0, 0), date(2024, time.February, 29, 0, 0), date(2024, time.March, 31, 0, 0)}),
+		Entry("31st skips short months", "FREQ=MONTHLY", date(2024, time.January, 31, 0, 0), date(2024, time.June, 1, 0, 0),
+			[]time.Time{date(2024, time.January, 31, 0, 0), date(2024, time.March, 31, 0, 0), date(2024, time.May, 31, 0, 0)}),
+		Entry("first and last day of a year", "FREQ=YEARLY;BYYEARDAY=1,-1", date(2024, time.January, 1, 0, 0), year.End,
+			[]time.Time{date(2024, time.January, 1, 0, 0), date(2024, time.December, 31, 0, 0)}),
+		Entry("Monday of week 20", "FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO", date(2024, time.January, 1, 0, 0), year.End,
+			[]time.Time{date(2024, time.May, 13, 0, 0)}),
+		Entry("hours of a day", "FREQ=DAILY;BYHOUR=9,17;BYMINUTE=30;COUNT=3", date(2024, time.January, 1, 0, 0), year.End,
+			[]time.Time{date(2024, time.January, 1, 9, 30), date(2024, time.January, 1, 17, 30), date(2024, time.January, 2, 9, 30)}),
+		Entry("hourly limited by BYHOUR", "FREQ=HOURLY;INTERVAL=4;BYHOUR=8,12", date(2024, time.January, 1, 0, 0), date(2024, time.January, 2, 9, 0),
+			[]time.Time{date(2024, time.January, 1, 8, 0), date(2024, time.January, 1, 12, 0), date(2024, time.January, 2, 8, 0)}),
+	)
+
+	It("maps FREQ and INTERVAL onto units", func() {
+		r, err := epoch.ParseRRule("RRULE:FREQ=WEEKLY;INTERVAL=2", time.UTC)
+		Expect(err).To(Succeed())
+		Expect(r.Freq).To(Equal(epoch.UnitWeek))
+		Expect(r.Step().String()).To(Equal("2w"))
+	})
+
+	It("numbers weeks within the week-year", func() {
+		years := epoch.NewRange(date(1997, time.January, 1, 0, 0), date(2005, time.January, 1, 0, 0))
+
+		r, _ := epoch.ParseRRule("FREQ=YEARLY;BYWEEKNO=1;BYDAY=MO", time.UTC)
+		Expect(epoch.NewRecurrence(years.Start, r).Between(years)).To(Equal([]time.Time{
+			date(1997, time.December, 29, 0, 0), date(1999, time.January, 4, 0, 0), date(2000, time.January, 3, 0, 0),
+			date(2001, time.January, 1, 0, 0), date(2001, time.December, 31, 0, 0), date(2002, time.December, 30, 0, 0),
+			date(2003, time.December, 29, 0, 0),
+		}))
+
+		r, _ = epoch.ParseRRule("FREQ=YEARLY;BYWEEKNO=53;BYDAY=MO", time.UTC)
+		Expect(epoch.NewRecurrence(years.Start, r).Between(years)).To(Equal([]time.Time{
+			date(1998, time.December, 28, 0, 0), date(2004, time.December, 27, 0, 0),
+		}))
+	})
+
+	It("counts excluded dates", func() {
+		r := epoch.RRule{Freq: epoch.UnitDay, Interval: 1, Count: 3}
+		rec := epoch.NewRecurrence(date(2024, time.January, 1, 9, 0), r)
+		rec.ExDates = []time.Time{date(2024, time.January, 2, 9, 0)}
+		Expect(rec.Between(year)).To(Equal([]time.Time{date(2024, time.January, 1, 9, 0), date(2024, time.January, 3, 9, 0)}))
+	})
+
+	It("iterates occurrences", func() {
+		r, _ := epoch.ParseRRule("FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29", time.UTC)
+		it := epoch.NewRecurrence(date(2024, time.February, 29, 0, 0), r).Iter()
+		t, ok := it.Next()
+		Expect(ok).To(BeTrue())
+		Expect(t).To(Equal(date(2024, time.February, 29, 0, 0)))
+		t, ok = it.Next()
+		Expect(ok).To(BeTrue())
+		Expect(t).To(Equal(date(2028, time.February, 29, 0, 0)))
+	})
+
+	It("stops on rules without occurrences", func() {
+		r, _ := epoch.ParseRRule("FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30", time.UTC)
+		started := time.Now()
+		_, ok := epoch.NewRecurrence(date(2024, time.January, 1, 0, 0), r).Iter().Next()
+		Expect(ok).To(BeFalse())
+		Expect(time.Since(started)).To(BeNumerically("<", time.Second))
+	})
+
+	It("finds occurrences after long gaps", func() {
+		r, _ := epoch.ParseRRule("FREQ=MINUTELY;BYMONTH=2", time.UTC)
+		t, ok := epoch.NewRecurrence(date(2024, time.March, 1, 0, 0), r).Iter().Next()
+		Expect(ok).To(BeTrue())
+		Expect(t).To(Equal(date(2025, time.February, 1, 0, 0)))
+
+		// February 29 on a Monday happens every 28 years (or more around 2100)
+		r, _ = epoch.ParseRRule("FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29;BYDAY=MO", time.UTC)
+		it := epoch.NewRecurrence(date(2016, time.February, 29, 0, 0), r).Iter()
+		var occurrences []time.Time
+		for t, ok := it.Next(); ok && t.Year() < 2200; t, ok = it.Next() {
+			occurrences = append(occurrences, t)
+		}
+		Expect(occurrences).To(Equal([]time.Time{
+			date(2016, time.February, 29, 0, 0), date(2044, time.February, 29, 0, 0), date(2072, time.February, 29, 0, 0),
+			date(2112, time.February, 29, 0, 0), date(2140, time.February, 29, 0, 0), date(2168, time.February, 29, 0, 0),
+			date(2196, time.February, 29, 0, 0),
+		}))
+	})
+
+	It("skips occurrences before the range", func() {
+		r, _ := epoch.ParseRRule("FREQ=MINUTELY;INTERVAL=7", time.UTC)
+		rec := epoch.NewRecurrence(date(2000, time.January, 1, 0, 3), r)
+		rec.Duration = 10 * time.Minute
+
+		within := epoch.NewRange(date(2024, time.January, 1, 0, 0), date(2024, time.January, 1, 0, 15))
+		started := time.Now()
+		occurrences := rec.Between(within)
+		Expect(time.Since(started)).To(BeNumerically("<", time.Second))
+		// 2024-01-01 00:00 is 2000-01-01 00:03 plus a multiple of 7 minutes
+		Expect(occurrences).To(Equal([]time.Time{date(2024, time.January, 1, 0, 0), date(2024, time.January, 1, 0, 7), date(2024, time.January, 1, 0, 14)}))
+
+		ranges := rec.Ranges(within)
+		Expect(ranges).To(HaveLen(4))
+		Expect(ranges[0].Start).To(Equal(date(2023, time.December, 31, 23, 53)))
+
+		r, _ = epoch.ParseRRule("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", time.UTC)
+		rec = epoch.NewRecurrence(date(2000, time.January, 3, 9, 0), r)
+		Expect(rec.Between(epoch.NewRange(date(2024, time.January, 1, 0, 0), date(2024, time.January, 20, 0, 0)))).To(Equal([]time.Time{
+			date(2024, time.January, 1, 9, 0), date(2024, time.January, 5, 9, 0), date(2024, time.January, 15, 9, 0), date(2024, time.January, 19, 9, 0),
+		}))
+	})
+
+	It("follows the wall clock across DST", func() {
+		r, _ := epoch.ParseRRule("FREQ=DAILY;COUNT=3", berlin)
+		rec := epoch.NewRecurrence(time.Date(2024, time.March, 30, 2, 30, 0, 0, berlin), r)
+		occurrences := rec.Between(year)
+		Expect(occurrences).To(HaveLen(3))
+		// 02:30 doesn't exist on 2024-03-31 and is shifted forward
+		Expect(occurrences[1].String()).To(Equal("2024-03-31 03:30:00 +0200 CEST"))
+		Expect(occurrences[2].String()).To(Equal("2024-04-01 02:30:00 +0200 CEST"))
+	})
+
+	It("shifts nonexistent times forward in America/New_York", func() {
+		newYork, _ := time.LoadLocation("America/New_York")
+
+		r, _ := epoch.ParseRRule("FREQ=DAILY;COUNT=3", newYork)
+		occurrences := epoch.NewRecurrence(time.Date(2024, time.March, 9, 2, 30, 0, 0, newYork), r).Between(year)
+		Expect(occurrences).To(HaveLen(3))
+		Expect(occurrences[1].String()).To(Equal("2024-03-10 03:30:00 -0400 EDT"))
+		Expect(occurrences[2].String()).To(Equal("2024-03-11 02:30:00 -0400 EDT"))
+
+		r, _ = epoch.ParseRRule("FREQ=HOURLY;COUNT=4", newYork)
+		occurrences = epoch.NewRecurrence(time.Date(2024, time.March, 10, 0, 30, 0, 0, newYork), r).Between(year)
+		var s []string
+		for _, t := range occurrences {
+			s = append(s, t.Format("15:04 MST"))
+		}
+		Expect(s).To(Equal([]string{"00:30 EST", "01:30 EST", "03:30 EDT", "04:30 EDT"}))
+	})
+
+	Context("ParseRecurrence", func() {
+		data := "DTSTART;TZID=Europe/Berlin:20240109T020000\r\n" +
+			"DTEND;TZID=Europe/Berlin:20240109T040000\r\n" +
+			"RRULE:FREQ=MONTHLY;BYDAY=2TU;COUNT=4\r\n" +
+			"EXDATE;TZID=Europe/Berlin:20240213T020000\r\n"
+
+		It("parses DTSTART, DTEND, RRULE and EXDATE", func() {
+			rec, err := epoch.ParseRecurrence(data, time.UTC)
+			Expect(err).To(Succeed())
+			Expect(rec.Start.Location().String()).To(Equal("Europe/Berlin"))
+			Expect(rec.Duration).To(Equal(2 * time.Hour))
+			Expect(rec.ExDates).To(HaveLen(1))
+			Expect(rec.Between(year)).To(HaveLen(3))
+		})
+
+		It("expands occurrences into ranges", func() {
+			rec, _ := epoch.ParseRecurrence(data, time.UTC)
+			// the window of January 9 overlaps the range
+			within := epoch.NewRange(time.Date(2024, time.January, 9, 3, 0, 0, 0, berlin), date(2024, time.April, 1, 0, 0))
+			ranges := rec.Ranges(within)
+			Expect(ranges).To(HaveLen(2))
+			Expect(ranges[0].String()).To(Equal("2024-01-09T02:00:00+01:00/2024-01-09T04:00:00+01:00"))
+			Expect(ranges[1].Start).To(Equal(time.Date(2024, time.March, 12, 2, 0, 0, 0, berlin)))
+		})
+
+		It("parses DURATION", func() {
+			rec, err := epoch.ParseRecurrence("DTSTART:20240101T000000Z\nDURATION:PT1H30M\nRRULE:FREQ=DAILY", nil)
+			Expect(err).To(Succeed())
+			Expect(rec.Duration).To(Equal(90 * time.Minute))
+		})
+	})
+
+	DescribeTable("invalid rules", func(rule string) {
+		_, err := epoch.ParseRRule(rule, time.UTC)
+		Expect(errors.Is(err, epoch.ErrInvalidRRule)).To(BeTrue(), rule)
+	},
+		Entry("no FREQ", "BYDAY=MO"),
+		Entry("unknown FREQ", "FREQ=FORTNIGHTLY"),
+		Entry("COUNT and UNTIL", "FREQ=DAILY;COUNT=3;UNTIL=20240101T000000Z"),
+		Entry("invalid weekday", "FREQ=WEEKLY;BYDAY=XX"),
+		Entry("out of range", "FREQ=MONTHLY;BYMONTHDAY=32"),
+		Entry("malformed part", "FREQ=DAILY;COUNT"),
+	)
+
+	It("rejects EXDATEs of another value type than DTSTART", func() {
+		_, err := epoch.ParseRecurrence("DTSTART:20240101T090000Z\nRRULE:FREQ=DAILY\nEXDATE;VALUE=DATE:20240105", nil)
+		Expect(err).To(MatchError(epoch.ErrInvalidRRule))
+
+		_, err = epoch.ParseRecurrence("EXDATE:20240105T090000Z\nDTSTART;VALUE=DATE:20240101\nRRULE:FREQ=DAILY", nil)
+		Expect(err).To(MatchError(epoch.ErrInvalidRRule))
+
+		rec, err := epoch.ParseRecurrence("DTSTART;VALUE=DATE:20240101\nRRULE:FREQ=DAILY;COUNT=3\nEXDATE;VALUE=DATE:20240102", nil)
+		Expect(err).To(Succeed())
+		Expect(rec.Between(year)).To(Equal([]time.Time{date(2024, time.January, 1, 0, 0), date(2024, time.January, 3, 0, 0)}))
+	})
+})
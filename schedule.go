@@ -0,0 +1,174 @@
+package epoch
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSchedule = fmt.Errorf("invalid schedule")
+)
+
+// maxScheduleSteps limits the search of the next fire time, so a broken schedule doesn't hang the caller
+const maxScheduleSteps = 1000
+
+// Schedule tells when a recurring job fires
+type Schedule interface {
+	// Next returns the first fire time strictly after t (zero time if there is none)
+	Next(t time.Time) time.Time
+}
+
+// ScheduleOption configures schedules and tickers
+type ScheduleOption func(*scheduleConfig)
+
+type scheduleConfig struct {
+	location *time.Location
+	offset   time.Duration
+	units    *UnitRegistry
+	jitter   time.Duration
+	rand     func(n int64) int64
+	cron     bool
+}
+
+func newScheduleConfig(options []ScheduleOption) scheduleConfig {
+	cfg := scheduleConfig{units: defaultUnitRegistry}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithScheduleLocation sets the location boundaries are aligned in (the location of the given time by default)
+func WithScheduleLocation(loc *time.Location) ScheduleOption {
+	return func(c *scheduleConfig) {
+		c.location = loc
+	}
+}
+
+// WithScheduleOffset shifts all the boundaries by d, e.g. every 1d with 2h offset fires at 02:00
+func WithScheduleOffset(d time.Duration) ScheduleOption {
+	return func(c *scheduleConfig) {
+		c.offset = d
+	}
+}
+
+// WithScheduleUnits sets the registry used to align and add intervals (built-in units by default)
+func WithScheduleUnits(units *UnitRegistry) ScheduleOption {
+	return func(c *scheduleConfig) {
+		c.units = units
+	}
+}
+
+var _ Schedule = &IntervalSchedule{}
+
+// IntervalSchedule fires at boundaries of an interval aligned on the wall clock,
+// e.g. every 15m fires at :00, :15, :30 and :45, every 1mo fires on the 1st (see UnitRegistry.TruncateToInterval).
+// It's immutable and safe for concurrent use.
+type IntervalSchedule struct {
+	interval Interval
+	location *time.Location
+	offset   time.Duration
+	units    *UnitRegistry
+}
+
+// NewIntervalSchedule returns a schedule firing at aligned boundaries of the interval
+func NewIntervalSchedule(i *Interval, options ...ScheduleOption) (*IntervalSchedule, error) {
+	cfg := newScheduleConfig(options)
+
+	if i.IsNil() {
+		return nil, fmt.Errorf("%w: no interval", ErrInvalidSchedule)
+	}
+	if _, ok := cfg.units.TruncateToInterval(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), i); !ok {
+		return nil, fmt.Errorf("%w: interval %s can't be aligned", ErrInvalidSchedule, i)
+	}
+
+	return &IntervalSchedule{
+		interval: *i,
+		location: cfg.location,
+		offset:   cfg.offset,
+		units:    cfg.units,
+	}, nil
+}
+
+// Interval returns the interval of the schedule
+func (s *IntervalSchedule) Interval() *Interval {
+	i := s.interval
+	return &i
+}
+
+// Truncate returns the last boundary at or before t (including the offset)
+func (s *IntervalSchedule) Truncate(t time.Time) time.Time {
+	if s.location != nil {
+		t = t.In(s.location)
+	}
+	b, _ := s.units.TruncateToInterval(t.Add(-s.offset), &s.interval)
+	return b.Add(s.offset)
+}
+
+// Next returns the first boundary after t
+func (s *IntervalSchedule) Next(t time.Time) time.Time {
+	if s.location != nil {
+		t = t.In(s.location)
+	}
+	t = t.Add(-s.offset)
+
+	b, _ := s.units.TruncateToInterval(t, &s.interval)
+	for i := 0; i < maxScheduleSteps; i++ {
+		next := s.units.AddInterval(b, &s.interval)
+		if aligned, _ := s.units.TruncateToInterval(next, &s.interval); aligned.After(b) {
+			next = aligned
+		}
+		// otherwise the wall clock repeats (DST ends), so the absolute step is kept
+
+		if next.After(t) {
+			return next.Add(s.offset)
+		}
+		b = next
+	}
+
+	return time.Time{}
+}
+
+// WithCron makes ParseSchedule accept cron expressions (e.g. "*/15 * * * *" or "@daily") besides intervals.
+// They are evaluated in the location given by WithScheduleLocation (UTC by default), see ParseCron.
+func WithCron() ScheduleOption {
+	return func(c *scheduleConfig) {
+		c.cron = true
+	}
+}
+
+// ParseSchedule parses a schedule given as an interval (e.g. "15m", "1mo"), see IntervalSchedule,
+// or as a cron expression if WithCron is given
+func ParseSchedule(s string, options ...ScheduleOption) (Schedule, error) {
+	cfg := newScheduleConfig(options)
+
+	if cfg.cron && (strings.ContainsAny(strings.TrimSpace(s), " \t") || strings.HasPrefix(s, "@")) {
+		c, err := ParseCron(s, cfg.location)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSchedule, err)
+		}
+		return c, nil
+	}
+
+	i, err := cfg.units.ParseInterval(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSchedule, err)
+	}
+	return NewIntervalSchedule(i, options...)
+}
+
+// Split returns consecutive periods between boundaries that cover the range.
+// The first period starts at the last boundary at or before r.Start, so periods are whole even if the range isn't aligned.
+func (s *IntervalSchedule) Split(r Range) []Range {
+	var periods []Range
+	for b := s.Truncate(r.Start); b.Before(r.End); {
+		next := s.Next(b)
+		if next.IsZero() {
+			break
+		}
+		periods = append(periods, Range{Start: b, End: next})
+		b = next
+	}
+	return periods
+}
@@ -0,0 +1,135 @@
+package epoch_test
+
+import (
+	"time"
+
+	"github.com/aahainc/epoch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schedules", func() {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	Context("TruncateToInterval", func() {
+		DescribeTable("aligns on the wall clock", func(t time.Time, interval string, expected time.Time) {
+			Expect(epoch.TruncateToInterval(t, epoch.MustParseInterval(interval))).To(Equal(expected))
+		},
+			Entry("15 minutes", date(2024, time.January, 10, 10, 7), "15m", date(2024, time.January, 10, 10, 0)),
+			Entry("7 minutes reset at midnight", date(2024, time.January, 10, 23, 58), "7m", date(2024, time.January, 10, 23, 55)),
+			Entry("2 hours", date(2024, time.January, 10, 11, 7), "2h", date(2024, time.January, 10, 10, 0)),
+			Entry("1.5 hours", date(2024, time.January, 10, 2, 0), "1.5h", date(2024, time.January, 10, 1, 30)),
+			Entry("2 days", date(2024, time.January, 31, 11, 0), "2d", date(2024, time.January, 31, 0, 0)),
+			Entry("2 weeks", date(2024, time.January, 10, 11, 0), "2w", date(2024, time.January, 8, 0, 0)),
+			Entry("1 month", date(2024, time.February, 29, 11, 0), "1mo", date(2024, time.February, 1, 0, 0)),
+			Entry("6 months", date(2024, time.November, 10, 11, 0), "6mo", date(2024, time.July, 1, 0, 0)),
+			Entry("2 quarters", date(2024, time.May, 10, 11, 0), "2q", date(2024, time.January, 1, 0, 0)),
+			Entry("10 years", date(2024, time.May, 10, 11, 0), "10y", date(2020, time.January, 1, 0, 0)),
+		)
+	})
+
+	Context("IntervalSchedule", func() {
+		DescribeTable("next boundary", func(interval string, loc *time.Location, t time.Time, expected time.Time) {
+			s, err := epoch.NewIntervalSchedule(epoch.MustParseInterval(interval), epoch.WithScheduleLocation(loc))
+			Expect(err).To(Succeed())
+			Expect(s.Next(t)).To(BeTemporally("==", expected))
+		},
+			Entry("15 minutes", "15m", time.UTC, date(2024, time.January, 10, 10, 7), date(2024, time.January, 10, 10, 15)),
+			Entry("15 minutes on a boundary", "15m", time.UTC, date(2024, time.January, 10, 10, 15), date(2024, time.January, 10, 10, 30)),
+			Entry("7 minutes over midnight", "7m", time.UTC, date(2024, time.January, 10, 23, 56), date(2024, time.January, 11, 0, 0)),
+			Entry("1 month from the end of January", "1mo", time.UTC, date(2024, time.January, 31, 10, 0), date(2024, time.February, 1, 0, 0)),
+			Entry("1 month on the 1st", "1mo", time.UTC, date(2024, time.February, 1, 0, 0), date(2024, time.March, 1, 0, 0)),
+			Entry("2 days at the end of the month", "2d", time.UTC, date(2024, time.January, 31, 0, 0), date(2024, time.February, 1, 0, 0)),
+			Entry("1 week", "1w", time.UTC, date(2024, time.January, 10, 10, 0), date(2024, time.January, 15, 0, 0)),
+			Entry("1 day in Berlin", "1d", berlin, date(2024, time.January, 10, 10, 0), date(2024, time.January, 10, 23, 0)),
+			// 02:00 doesn't exist on March 31 in Berlin, 03:00 CEST is 01:00 UTC
+			Entry("1 hour when DST starts", "1h", berlin, date(2024, time.March, 31, 0, 30), date(2024, time.March, 31, 1, 0)),
+			Entry("1 day when DST starts", "1d", berlin, date(2024, time.March, 30, 23, 30), date(2024, time.March, 31, 22, 0)),
+			// 02:00-03:00 happens twice on October 27 in Berlin
+			Entry("1 hour when DST ends", "1h", berlin, date(2024, time.October, 27, 0, 30), date(2024, time.October, 27, 1, 0)),
+			Entry("1 hour after DST ends", "1h", berlin, date(2024, time.October, 27, 1, 0), date(2024, time.October, 27, 2, 0)),
+		)
+
+		It("fires every hour when DST ends", func() {
+			s, err := epoch.NewIntervalSchedule(epoch.MustParseInterval("1h"), epoch.WithScheduleLocation(berlin))
+			Expect(err).To(Succeed())
+
+			t := date(2024, time.October, 26, 22, 0)
+			for i := 0; i < 6; i++ {
+				next := s.Next(t)
+				Expect(next.Sub(t)).To(Equal(time.Hour))
+				t = next
+			}
+		})
+
+		It("shifts boundaries by the offset", func() {
+			s, err := epoch.NewIntervalSchedule(epoch.MustParseInterval("1d"), epoch.WithScheduleOffset(2*time.Hour))
+			Expect(err).To(Succeed())
+			Expect(s.Next(date(2024, time.January, 1, 1, 0))).To(Equal(date(2024, time.January, 1, 2, 0)))
+			Expect(s.Next(date(2024, time.January, 1, 2, 0))).To(Equal(date(2024, time.January, 2, 2, 0)))
+			Expect(s.Truncate(date(2024, time.January, 1, 1, 0))).To(Equal(date(2023, time.December, 31, 2, 0)))
+		})
+
+		It("rejects intervals that can't be aligned", func() {
+			_, err := epoch.NewIntervalSchedule(epoch.MustParseInterval("0m"))
+			Expect(err).To(MatchError(epoch.ErrInvalidSchedule))
+			_, err = epoch.NewIntervalSchedule(epoch.MustParseInterval("1.5mo"))
+			Expect(err).To(MatchError(epoch.ErrInvalidSchedule))
+			_, err = epoch.ParseSchedule("every day")
+			Expect(err).To(MatchError(epoch.ErrInvalidSchedule))
+		})
+	})
+
+	Context("AlignedTicker", func() {
+		It("ticks at aligned boundaries of a fake clock", func() {
+			clock := epoch.NewFakeClock(date(2024, time.January, 10, 10, 7))
+			ticker, err := epoch.NewAlignedTicker(epoch.MustParseInterval("15m"), clock, epoch.WithScheduleLocation(time.UTC))
+			Expect(err).To(Succeed())
+			defer ticker.Stop()
+
+			clock.BlockUntil(1)
+			clock.Advance(7 * time.Minute)
+			Consistently(ticker.C, "10ms").ShouldNot(Receive())
+
+			clock.Advance(time.Minute)
+			Eventually(ticker.C).Should(Receive(Equal(date(2024, time.January, 10, 10, 15))))
+
+			// missed ticks are skipped
+			clock.BlockUntil(1)
+			clock.Set(date(2024, time.January, 10, 11, 5))
+			Eventually(ticker.C).Should(Receive(Equal(date(2024, time.January, 10, 10, 30))))
+			clock.BlockUntil(1)
+			clock.Set(date(2024, time.January, 10, 11, 15))
+			Eventually(ticker.C).Should(Receive(Equal(date(2024, time.January, 10, 11, 15))))
+		})
+
+		It("delays ticks by jitter", func() {
+			clock := epoch.NewFakeClock(date(2024, time.January, 10, 10, 7))
+			ticker, err := epoch.NewAlignedTicker(epoch.MustParseInterval("15m"), clock,
+				epoch.WithJitter(time.Minute),
+				epoch.WithJitterSource(func(n int64) int64 { return n / 2 }),
+			)
+			Expect(err).To(Succeed())
+			defer ticker.Stop()
+
+			clock.BlockUntil(1)
+			clock.Set(date(2024, time.January, 10, 10, 15))
+			Consistently(ticker.C, "10ms").ShouldNot(Receive())
+
+			clock.Advance(30 * time.Second)
+			Eventually(ticker.C).Should(Receive(Equal(date(2024, time.January, 10, 10, 15))))
+		})
+
+		It("stops", func() {
+			clock := epoch.NewFakeClock(date(2024, time.January, 10, 10, 7))
+			ticker, err := epoch.NewAlignedTicker(epoch.MustParseInterval("1mo"), clock)
+			Expect(err).To(Succeed())
+
+			clock.BlockUntil(1)
+			ticker.Stop()
+			ticker.Stop()
+			clock.Set(date(2024, time.March, 1, 0, 0))
+			Consistently(ticker.C, "10ms").ShouldNot(Receive())
+		})
+	})
+})
@@ -0,0 +1,114 @@
+package epoch
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// WithJitter delays every tick of a ticker by a random duration in [0, max),
+// so many processes with the same schedule don't fire at once
+func WithJitter(max time.Duration) ScheduleOption {
+	return func(c *scheduleConfig) {
+		c.jitter = max
+	}
+}
+
+// WithJitterSource sets the source of random numbers for jitter (rand.Int63n by default).
+// It's called with the jitter in nanoseconds and must return a number in [0, n).
+func WithJitterSource(int63n func(n int64) int64) ScheduleOption {
+	return func(c *scheduleConfig) {
+		c.rand = int63n
+	}
+}
+
+// AlignedTicker delivers ticks at times of a Schedule, e.g. every 15m aligned on the wall clock.
+// Unlike time.Ticker it doesn't drift: each tick is computed from the schedule, not from the previous one.
+//
+// The time sent on C is the scheduled time (without jitter). As with time.Ticker,
+// ticks are dropped if the receiver is slow.
+type AlignedTicker struct {
+	C <-chan time.Time
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewAlignedTicker returns a ticker firing at aligned boundaries of the interval (see IntervalSchedule).
+// Timers of the clock are used if it's a TimerClock (e.g. FakeClock), timers of the time package otherwise.
+// If nil clock is given, the default one is used.
+func NewAlignedTicker(i *Interval, clock Clock, options ...ScheduleOption) (*AlignedTicker, error) {
+	s, err := NewIntervalSchedule(i, options...)
+	if err != nil {
+		return nil, err
+	}
+	return NewScheduleTicker(s, clock, options...), nil
+}
+
+// NewScheduleTicker returns a ticker firing at times of the given schedule (e.g. parsed by ParseSchedule).
+// Only jitter options are used.
+func NewScheduleTicker(s Schedule, clock Clock, options ...ScheduleOption) *AlignedTicker {
+	cfg := newScheduleConfig(options)
+	if cfg.rand == nil {
+		cfg.rand = rand.Int63n
+	}
+	if clock == nil {
+		clock = NewDefaultClock()
+	}
+	timers, ok := clock.(TimerClock)
+	if !ok {
+		timers = NewDefaultClock()
+	}
+
+	c := make(chan time.Time, 1)
+	t := &AlignedTicker{
+		C:    c,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go t.run(s, clock, timers, cfg, c)
+	return t
+}
+
+func (t *AlignedTicker) run(s Schedule, clock Clock, timers TimerClock, cfg scheduleConfig, c chan<- time.Time) {
+	defer close(t.done)
+
+	now := clock.Now()
+	for {
+		next := s.Next(now)
+		if next.IsZero() {
+			return
+		}
+
+		fireAt := next
+		if cfg.jitter > 0 {
+			fireAt = fireAt.Add(time.Duration(cfg.rand(int64(cfg.jitter))))
+		}
+
+		timer := timers.NewTimer(fireAt.Sub(clock.Now()))
+		select {
+		case <-t.stop:
+			timer.Stop()
+			return
+		case <-timer.C():
+		}
+
+		select {
+		case c <- next:
+		default:
+		}
+
+		// missed ticks are skipped if the clock jumped forward
+		now = clock.Now()
+		if now.Before(next) {
+			now = next
+		}
+	}
+}
+
+// Stop turns off the ticker. No more ticks are sent after it returns
+func (t *AlignedTicker) Stop() {
+	t.stopOnce.Do(func() { close(t.stop) })
+	<-t.done
+}
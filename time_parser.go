@@ -3,12 +3,23 @@ package epoch
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 )
 
 type TimeParser struct {
-	parsers                 []Parser
+	// parsers is the list of active parsers in order of matching (see parser_registry.go)
+	parsers []Parser
+	// registry holds all registered parsers, including disabled ones
+	registry []*parserEntry
+	mu       sync.RWMutex
+
+	units                   *UnitRegistry
+	clock                   Clock
+	location                *time.Location
 	withIntervalArithmetics bool
+	strictAmbiguity         bool
+	trace                   bool
 }
 
 type TimeParserOption func(*TimeParser)
@@ -20,6 +31,16 @@ func WithIntervalArithmetics() TimeParserOption {
 	}
 }
 
+// WithStrictAmbiguity makes parsing fail with ErrAmbiguousTime
+// when several parsers match the input and give different times (see TimeParser.ParseAll).
+// Only active parsers are checked: default parsers don't include compact dates, so "20240101" is reported
+// as ambiguous only with the compact date parser enabled (e.g. WithParsers(GetAllParsers()...)).
+func WithStrictAmbiguity() TimeParserOption {
+	return func(tp *TimeParser) {
+		tp.strictAmbiguity = true
+	}
+}
+
 // WithBaseTimeFormat sets time format that is used by BaseParser
 func WithBaseTimeFormat(v string) TimeParserOption {
 	return func(tp *TimeParser) {
@@ -27,6 +48,29 @@ func WithBaseTimeFormat(v string) TimeParserOption {
 	}
 }
 
+// WithUnits sets the unit registry used for interval arithmetics,
+// so user-defined units (e.g. "sprint") can be used in time expressions
+func WithUnits(units *UnitRegistry) TimeParserOption {
+	return func(tp *TimeParser) {
+		tp.units = units
+	}
+}
+
+// WithClock sets the clock for all clock-aware parsers (see ClockAware), e.g. AliasesParser
+func WithClock(c Clock) TimeParserOption {
+	return func(tp *TimeParser) {
+		tp.clock = c
+	}
+}
+
+// WithLocation sets the default location used when no locArg is given.
+// It's propagated to all location-aware parsers (see LocationAware)
+func WithLocation(loc *time.Location) TimeParserOption {
+	return func(tp *TimeParser) {
+		tp.location = loc
+	}
+}
+
 // WithDefaultParsers sets the default list of parsers for TimeParser
 func WithDefaultParsers() TimeParserOption {
 	return func(tp *TimeParser) {
@@ -44,7 +88,7 @@ func WithParsers(parsers ...Parser) TimeParserOption {
 // NewTimeParser creates a new instance of TimeParser with the provided options.
 // If given options attach no parsers, it will use default parsers
 func NewTimeParser(options ...TimeParserOption) *TimeParser {
-	tp := &TimeParser{}
+	tp := &TimeParser{units: defaultUnitRegistry}
 	for _, opt := range options {
 		opt(tp)
 	}
@@ -53,9 +97,55 @@ func NewTimeParser(options ...TimeParserOption) *TimeParser {
 		WithDefaultParsers()(tp)
 	}
 
+	if tp.units == nil {
+		tp.units = defaultUnitRegistry
+	}
+
+	// the given slice and parsers are left as they are (see configureParser)
+	parsers := make([]Parser, len(tp.parsers))
+	for i, parser := range tp.parsers {
+		parsers[i] = tp.configureParser(parser)
+		tp.registry = append(tp.registry, &parserEntry{parser: parsers[i], enabled: true})
+	}
+	tp.parsers = parsers
+
 	return tp
 }
 
+// configureParser returns a copy of the parser with the clock and the location of TimeParser.
+// The given parser isn't mutated, since it may be shared with other TimeParsers:
+// parsers that can't be cloned (see CloneableParser) are returned as they are.
+func (tp *TimeParser) configureParser(parser Parser) Parser {
+	cloneable, ok := parser.(CloneableParser)
+	if !ok || (tp.clock == nil && tp.location == nil) {
+		return parser
+	}
+	parser = cloneable.Clone()
+
+	if c, ok := parser.(ClockAware); ok && tp.clock != nil {
+		c.UseClock(tp.clock)
+	}
+	if l, ok := parser.(LocationAware); ok && tp.location != nil {
+		l.UseLocation(tp.location)
+	}
+	return parser
+}
+
+// Clock returns the clock set by WithClock (nil if it's not set)
+func (tp *TimeParser) Clock() Clock {
+	return tp.clock
+}
+
+// Location returns the location set by WithLocation (nil if it's not set)
+func (tp *TimeParser) Location() *time.Location {
+	return tp.location
+}
+
+// Units returns the unit registry used by the parser
+func (tp *TimeParser) Units() *UnitRegistry {
+	return tp.units
+}
+
 // Parse attempts to parse the given string using the list of parsers.
 func (tp *TimeParser) Parse(s string, locArg ...*time.Location) (time.Time, error) {
 	t, _, err := tp.ParseExt(s, locArg...)
@@ -64,49 +154,45 @@ func (tp *TimeParser) Parse(s string, locArg ...*time.Location) (time.Time, erro
 
 // ParseExt attempts to parse the given string using the list of parsers.
 func (tp *TimeParser) ParseExt(s string, locArg ...*time.Location) (time.Time, *ParseDetails, error) {
-	inputs := strings.Split(s, ",")
-	t, details, err := tp.parseTime(inputs[0], locArg...)
-	if err != nil {
-		return time.Time{}, nil, fmt.Errorf("failed to parse time: %w", err)
-	}
-
-	// if we have just one input, not
-	if len(inputs) == 1 {
-		return t, details, nil
-	}
-
-	// comma-separated value are allowed only if interval arithmetics is enabled
-	if !tp.withIntervalArithmetics {
-		return time.Time{}, nil, fmt.Errorf("unsupported time format")
-	}
-
-	// Parse and apply each interval in given input
-	for i := 1; i < len(inputs); i++ {
-		interval, err := ParseInterval(inputs[i])
+	// a plain time has nothing to compile
+	if !tp.trace && !strings.ContainsAny(s, ",@") && !strings.Contains(s, " TZ=") {
+		t, details, _, err := tp.parseTime(s, locArg...)
 		if err != nil {
-			return time.Time{}, nil, fmt.Errorf("failed to parse interval [%s]: %w", inputs[i], err)
+			return time.Time{}, nil, fmt.Errorf("failed to parse time: %w", err)
 		}
+		details.Location = locationName(t)
+		return t, details, nil
+	}
 
-		t = TimeAddInterval(t, interval)
+	e, t, details, err := tp.compile(s, locArg...)
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("failed to parse time: %w", err)
 	}
 
+	t, details = e.applyTraced(t, details, locArg)
 	return t, details, nil
 }
 
-// parseTime parses the given string using the list of parsers only (no interval arithmetic is applied)
-func (tp *TimeParser) parseTime(s string, locArg ...*time.Location) (time.Time, *ParseDetails, error) {
-	for _, parser := range tp.parsers {
-		if !parser.Match(s) {
+// parseTime parses the given string using the list of parsers only (no interval arithmetic is applied).
+// It also returns the parser that was chosen.
+func (tp *TimeParser) parseTime(s string, locArg ...*time.Location) (time.Time, *ParseDetails, Parser, error) {
+	for _, parser := range tp.activeParsers() {
+		t, details, ok, err := tryParse(parser, s, locArg...)
+		if !ok {
 			continue
 		}
-
-		t, details, err := parser.Parse(s, locArg...)
 		if err != nil {
-			return time.Time{}, nil, fmt.Errorf("failed to parse time: %w", err)
+			return time.Time{}, nil, nil, err
 		}
 
-		return t, details, nil
+		if tp.strictAmbiguity {
+			if err := tp.checkAmbiguity(s, t, parser, locArg...); err != nil {
+				return time.Time{}, nil, nil, err
+			}
+		}
+
+		return t, details, parser, nil
 	}
 
-	return time.Time{}, nil, fmt.Errorf("unsupported time format")
+	return time.Time{}, nil, nil, fmt.Errorf("unsupported time format")
 }
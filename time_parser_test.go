@@ -1,6 +1,8 @@
 package epoch_test
 
 import (
+	"errors"
+
 	"github.com/aahainc/epoch"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -114,3 +116,194 @@ var _ = Describe("TimeParser", func() {
 		})
 	})
 })
+
+var _ = Describe("TimeParser options", func() {
+	fixedNow := time.Date(2006, time.January, 2, 22, 4, 5, 0, time.UTC)
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	BeforeEach(func() {
+		epoch.BaseParserFormat = time.RFC3339
+	})
+
+	It("propagates the clock to clock-aware parsers", func() {
+		p := epoch.NewTimeParser(epoch.WithClock(epoch.NewStaticClock(fixedNow)))
+		t, err := p.Parse("today", time.UTC)
+		Expect(err).Should(Succeed())
+		Expect(t).To(Equal(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)))
+		Expect(p.Clock()).NotTo(BeNil())
+	})
+
+	It("propagates the location to location-aware parsers", func() {
+		p := epoch.NewTimeParser(
+			epoch.WithParsers(epoch.NewBaseParser(), epoch.NewUnixSecondsParser(), epoch.NewAliasesParser()),
+			epoch.WithClock(epoch.NewStaticClock(fixedNow)),
+			epoch.WithLocation(tokyo),
+		)
+		Expect(p.Location()).To(Equal(tokyo))
+
+		t, err := p.Parse("today")
+		Expect(err).Should(Succeed())
+		Expect(t).To(Equal(time.Date(2006, time.January, 3, 0, 0, 0, 0, tokyo)))
+
+		t, err = p.Parse("1136239445")
+		Expect(err).Should(Succeed())
+		Expect(t.Location()).To(Equal(tokyo))
+
+		// explicit locArg still wins
+		t, err = p.Parse("today", time.UTC)
+		Expect(err).Should(Succeed())
+		Expect(t).To(Equal(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)))
+	})
+
+	It("propagates options given before parsers", func() {
+		p := epoch.NewTimeParser(
+			epoch.WithClock(epoch.NewStaticClock(fixedNow)),
+			epoch.WithParsers(epoch.NewAliasesParser()),
+		)
+		t, err := p.Parse("tomorrow", time.UTC)
+		Expect(err).Should(Succeed())
+		Expect(t).To(Equal(time.Date(2006, time.January, 3, 0, 0, 0, 0, time.UTC)))
+	})
+
+	It("parses wall clock in the default location", func() {
+		epoch.BaseParserFormat = "2006-01-02 15:04"
+		defer func() { epoch.BaseParserFormat = time.RFC3339 }()
+
+		p := epoch.NewTimeParser(epoch.WithLocation(tokyo))
+		t, err := p.Parse("2020-01-02 16:30")
+		Expect(err).Should(Succeed())
+		Expect(t).To(Equal(time.Date(2020, time.January, 2, 16, 30, 0, 0, tokyo)))
+	})
+
+	It("propagates options to user-defined parsers", func() {
+		custom := &recordingParser{}
+		epoch.NewTimeParser(
+			epoch.WithParsers(epoch.NewBaseParser(), custom),
+			epoch.WithClock(epoch.NewStaticClock(fixedNow)),
+			epoch.WithLocation(tokyo),
+		)
+		Expect(custom.clone.clock.Now()).To(Equal(fixedNow))
+		Expect(custom.clone.location).To(Equal(tokyo))
+
+		// the given instance is left as it is
+		Expect(custom.clock).To(BeNil())
+		Expect(custom.location).To(BeNil())
+	})
+
+	It("doesn't mutate parsers shared by several TimeParsers", func() {
+		berlin, _ := time.LoadLocation("Europe/Berlin")
+		aliases := epoch.NewAliasesParser()
+		clock := epoch.WithClock(epoch.NewStaticClock(fixedNow))
+
+		inTokyo := epoch.NewTimeParser(epoch.WithParsers(aliases), clock, epoch.WithLocation(tokyo))
+		inBerlin := epoch.NewTimeParser(epoch.WithParsers(epoch.NewBaseParser()), clock, epoch.WithLocation(berlin))
+		Expect(inBerlin.Register(aliases)).To(Succeed())
+
+		t, err := inTokyo.Parse("today")
+		Expect(err).Should(Succeed())
+		Expect(t).To(Equal(time.Date(2006, time.January, 3, 0, 0, 0, 0, tokyo)))
+
+		t, err = inBerlin.Parse("today")
+		Expect(err).Should(Succeed())
+		Expect(t).To(Equal(time.Date(2006, time.January, 2, 0, 0, 0, 0, berlin)))
+	})
+})
+
+var _ = Describe("User-defined parsers", func() {
+	It("may return no details", func() {
+		p := epoch.NewTimeParser(epoch.WithParsers(nilDetailsParser{}), epoch.WithIntervalArithmetics())
+		epoch0 := time.Unix(0, 0).UTC()
+
+		t, details, err := p.ParseExt("epoch")
+		Expect(err).Should(Succeed())
+		Expect(t).To(Equal(epoch0))
+		Expect(details.ParserName).To(Equal("nil-details"))
+
+		t, err = p.Parse("epoch,+1d")
+		Expect(err).Should(Succeed())
+		Expect(t).To(Equal(epoch0.AddDate(0, 0, 1)))
+
+		e, err := p.Compile("epoch,/d")
+		Expect(err).Should(Succeed())
+		t, err = e.Eval(nil, nil)
+		Expect(err).Should(Succeed())
+		Expect(t).To(Equal(epoch0))
+
+		candidates, err := p.ParseAll("epoch")
+		Expect(err).Should(Succeed())
+		Expect(candidates).To(HaveLen(1))
+
+		res := p.ParseBatch([]string{"epoch", "epoch", "epoch"}, epoch.WithBatchSampleSize(1))
+		Expect(res.Summary.Failed).To(BeZero())
+		Expect(res.Summary.Parser).To(Equal("nil-details"))
+	})
+})
+
+var _ = Describe("TryParser", func() {
+	BeforeEach(func() {
+		epoch.BaseParserFormat = time.RFC3339
+	})
+
+	DescribeTable("gives the same result as Match and Parse", func(parser epoch.Parser, input string) {
+		t, details, ok, err := parser.(epoch.TryParser).TryParse(input, time.UTC)
+		Expect(ok).To(Equal(parser.Match(input)))
+		if !ok {
+			Expect(err).To(Succeed())
+			return
+		}
+
+		tExpected, detailsExpected, errExpected := parser.Parse(input, time.UTC)
+		Expect(errExpected).To(Succeed())
+		Expect(err).To(Succeed())
+		Expect(t.Sub(tExpected)).To(BeNumerically("<", time.Second))
+		Expect(details.ParserName).To(Equal(detailsExpected.ParserName))
+	},
+		Entry("base", epoch.NewBaseParser(), "2018-03-01T12:30:00Z"),
+		Entry("base with offset", epoch.NewBaseParser(), "2018-03-01T12:30:00-07:00"),
+		Entry("base mismatch", epoch.NewBaseParser(), "1577829600"),
+		Entry("unix seconds", epoch.NewUnixSecondsParser(), "1577829600"),
+		Entry("unix seconds mismatch", epoch.NewUnixSecondsParser(), "1577829600000"),
+		Entry("unix milli", epoch.NewUnixMilliParser(), "1577829600000"),
+		Entry("unix milli mismatch", epoch.NewUnixMilliParser(), "today"),
+		Entry("compact date", epoch.NewCompactDateParser(), "20240101"),
+		Entry("compact date mismatch", epoch.NewCompactDateParser(), "20241301"),
+		Entry("aliases", epoch.NewAliasesParser(), "yesterday"),
+		Entry("aliases mismatch", epoch.NewAliasesParser(), "someday"),
+	)
+
+	It("still supports parsers without TryParse", func() {
+		p := epoch.NewTimeParser(epoch.WithParsers(&recordingParser{}, epoch.NewUnixSecondsParser()))
+		t, err := p.Parse("1577829600")
+		Expect(err).To(Succeed())
+		Expect(t.Unix()).To(Equal(int64(1577829600)))
+	})
+})
+
+// nilDetailsParser is a user-defined parser that returns no details
+type nilDetailsParser struct{}
+
+func (nilDetailsParser) Match(s string) bool { return s == "epoch" }
+func (nilDetailsParser) Name() string        { return "nil-details" }
+func (nilDetailsParser) Parse(string, ...*time.Location) (time.Time, *epoch.ParseDetails, error) {
+	return time.Unix(0, 0).UTC(), nil, nil
+}
+
+// recordingParser is a user-defined parser recording the clock and the location it's given
+type recordingParser struct {
+	clock    epoch.Clock
+	location *time.Location
+	clone    *recordingParser
+}
+
+func (r *recordingParser) Clone() epoch.Parser {
+	r.clone = &recordingParser{}
+	return r.clone
+}
+
+func (r *recordingParser) Match(string) bool { return false }
+func (r *recordingParser) Name() string      { return "recording" }
+func (r *recordingParser) Parse(string, ...*time.Location) (time.Time, *epoch.ParseDetails, error) {
+	return time.Time{}, nil, errors.New("not implemented")
+}
+func (r *recordingParser) UseClock(c epoch.Clock)         { r.clock = c }
+func (r *recordingParser) UseLocation(loc *time.Location) { r.location = loc }
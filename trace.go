@@ -0,0 +1,136 @@
+package epoch
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TraceStepKind is a kind of step of a Trace
+type TraceStepKind string
+
+const (
+	// TraceStepAnchor resolves the anchor of an expression by a parser, e.g. "today"
+	TraceStepAnchor TraceStepKind = "anchor"
+	// TraceStepInterval adds an interval, e.g. "-1d"
+	TraceStepInterval TraceStepKind = "interval"
+	// TraceStepRounding rounds the time down to the start of a unit, e.g. "/w"
+	TraceStepRounding TraceStepKind = "rounding"
+)
+
+// Location sources tell where the location of a parsed time comes from
+const (
+	// LocationSourceQualifier is a zone qualifier of the expression, e.g. "today@Europe/Berlin"
+	LocationSourceQualifier = "qualifier"
+	// LocationSourceArgument is the location passed to Parse
+	LocationSourceArgument = "argument"
+	// LocationSourceParser is the location set by WithLocation
+	LocationSourceParser = "parser"
+	// LocationSourceInput is the parsed input itself (an offset in the string, UTC for unix timestamps)
+	LocationSourceInput = "input"
+)
+
+// TraceStep is a single step of computing a time
+type TraceStep struct {
+	Kind TraceStepKind `json:"kind"`
+	// Input is the part of the expression of the step, e.g. "today" or "-1d"
+	Input string `json:"input"`
+	// Before is the time before the step (nil for the anchor)
+	Before *time.Time `json:"before,omitempty"`
+	After  time.Time  `json:"after"`
+	// Parser is the name of the parser that resolved the anchor
+	Parser string `json:"parser,omitempty"`
+	// Interval is the interval added by an interval step
+	Interval *Interval `json:"interval,omitempty"`
+	// Unit is the short name of the unit of a rounding step
+	Unit string `json:"unit,omitempty"`
+}
+
+// Trace records how a time was computed from an expression step by step (see WithTrace).
+// It can be rendered as text (String) or JSON.
+type Trace struct {
+	Input string `json:"input"`
+	// Location is the name of the location the time was computed in
+	Location string `json:"location"`
+	// LocationSource tells where the location comes from, one of LocationSource* constants
+	LocationSource string      `json:"location_source"`
+	Steps          []TraceStep `json:"steps"`
+	Result         time.Time   `json:"result"`
+}
+
+// WithTrace makes ParseExt (and EvalExt of compiled expressions) record a Trace in ParseDetails
+func WithTrace() TimeParserOption {
+	return func(tp *TimeParser) {
+		tp.trace = true
+	}
+}
+
+// newTrace starts a trace of the expression evaluated from the anchor time
+func (e *Expression) newTrace(anchor time.Time, details *ParseDetails, locArg []*time.Location) *Trace {
+	source := LocationSourceInput
+	switch {
+	case e.location != nil:
+		source = LocationSourceQualifier
+	case len(locArg) > 0 && locArg[0] != nil:
+		source = LocationSourceArgument
+	case e.parserLocation != nil:
+		source = LocationSourceParser
+	}
+
+	return &Trace{
+		Input:          e.source,
+		Location:       locationName(anchor),
+		LocationSource: source,
+		Steps: []TraceStep{{
+			Kind:   TraceStepAnchor,
+			Input:  e.anchor,
+			After:  anchor,
+			Parser: details.ParserName,
+		}},
+	}
+}
+
+// add records the step of the operation
+func (t *Trace) add(op ExpressionOp, before, after time.Time) {
+	step := TraceStep{Input: op.Raw, Before: &before, After: after}
+	switch op.Kind {
+	case ExpressionOpAddInterval:
+		step.Kind = TraceStepInterval
+		step.Interval = op.Interval
+	case ExpressionOpTruncate:
+		step.Kind = TraceStepRounding
+		step.Unit = op.Unit.Short
+	}
+	t.Steps = append(t.Steps, step)
+}
+
+// String renders the trace as text, e.g.
+//
+//	input:    today,-1d,/w
+//	location: Europe/Berlin (argument)
+//	1. anchor    today  ->  2024-01-10T00:00:00+01:00 (aliases)
+//	2. interval  -1d    2024-01-10T00:00:00+01:00  ->  2024-01-09T00:00:00+01:00
+//	3. rounding  /w     2024-01-09T00:00:00+01:00  ->  2024-01-08T00:00:00+01:00
+//	result:   2024-01-08T00:00:00+01:00
+func (t *Trace) String() string {
+	width := 0
+	for _, s := range t.Steps {
+		if len(s.Input) > width {
+			width = len(s.Input)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "input:    %s\n", t.Input)
+	fmt.Fprintf(&b, "location: %s (%s)\n", t.Location, t.LocationSource)
+	for i, s := range t.Steps {
+		fmt.Fprintf(&b, "%d. %-8s  %-*s  ", i+1, s.Kind, width, s.Input)
+		if s.Before != nil {
+			fmt.Fprintf(&b, "%s  ->  %s\n", s.Before.Format(time.RFC3339Nano), s.After.Format(time.RFC3339Nano))
+		} else {
+			fmt.Fprintf(&b, "->  %s (%s)\n", s.After.Format(time.RFC3339Nano), s.Parser)
+		}
+	}
+	fmt.Fprintf(&b, "result:   %s\n", t.Result.Format(time.RFC3339Nano))
+	return b.String()
+}
@@ -0,0 +1,106 @@
+package epoch_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/aahainc/epoch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Trace", func() {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	// 2024-01-10 is Wednesday
+	now := time.Date(2024, time.January, 10, 12, 30, 0, 0, time.UTC)
+	clock := epoch.NewFakeClock(now)
+
+	BeforeEach(func() {
+		epoch.BaseParserFormat = time.RFC3339
+	})
+
+	It("isn't recorded by default", func() {
+		p := epoch.NewTimeParser(epoch.WithIntervalArithmetics(), epoch.WithClock(clock))
+		_, details, err := p.ParseExt("today,-1d")
+		Expect(err).To(Succeed())
+		Expect(details.Trace).To(BeNil())
+	})
+
+	It("records each step", func() {
+		p := epoch.NewTimeParser(epoch.WithIntervalArithmetics(), epoch.WithClock(clock), epoch.WithTrace())
+		t, details, err := p.ParseExt("today,-1d,/w,+9h", berlin)
+		Expect(err).To(Succeed())
+
+		trace := details.Trace
+		Expect(trace).NotTo(BeNil())
+		Expect(trace.Input).To(Equal("today,-1d,/w,+9h"))
+		Expect(trace.Location).To(Equal("Europe/Berlin"))
+		Expect(trace.LocationSource).To(Equal(epoch.LocationSourceArgument))
+		Expect(trace.Result).To(Equal(t))
+
+		Expect(trace.Steps).To(HaveLen(4))
+		anchor := trace.Steps[0]
+		Expect(anchor.Kind).To(Equal(epoch.TraceStepAnchor))
+		Expect(anchor.Parser).To(Equal(epoch.ParserNameAliases))
+		Expect(anchor.Before).To(BeNil())
+		Expect(anchor.After).To(Equal(time.Date(2024, time.January, 10, 0, 0, 0, 0, berlin)))
+
+		interval := trace.Steps[1]
+		Expect(interval.Kind).To(Equal(epoch.TraceStepInterval))
+		Expect(interval.Interval).To(Equal(epoch.MustParseInterval("-1d")))
+		Expect(*interval.Before).To(Equal(anchor.After))
+		Expect(interval.After).To(Equal(time.Date(2024, time.January, 9, 0, 0, 0, 0, berlin)))
+
+		rounding := trace.Steps[2]
+		Expect(rounding.Kind).To(Equal(epoch.TraceStepRounding))
+		Expect(rounding.Unit).To(Equal("w"))
+		Expect(rounding.After).To(Equal(time.Date(2024, time.January, 8, 0, 0, 0, 0, berlin)))
+
+		Expect(trace.Steps[3].After).To(Equal(t))
+	})
+
+	DescribeTable("location source", func(options []epoch.TimeParserOption, input string, locArg []*time.Location, expected string) {
+		options = append(options, epoch.WithIntervalArithmetics(), epoch.WithClock(clock), epoch.WithTrace())
+		_, details, err := epoch.NewTimeParser(options...).ParseExt(input, locArg...)
+		Expect(err).To(Succeed())
+		Expect(details.Trace.LocationSource).To(Equal(expected))
+	},
+		Entry("qualifier", nil, "today@Asia/Tokyo", []*time.Location{berlin}, epoch.LocationSourceQualifier),
+		Entry("argument", nil, "today", []*time.Location{berlin}, epoch.LocationSourceArgument),
+		Entry("parser", []epoch.TimeParserOption{epoch.WithLocation(berlin)}, "today", nil, epoch.LocationSourceParser),
+		Entry("input", nil, "2024-01-10T10:00:00+05:00", nil, epoch.LocationSourceInput),
+	)
+
+	It("is recorded by compiled expressions", func() {
+		p := epoch.NewTimeParser(epoch.WithIntervalArithmetics(), epoch.WithTrace())
+		e, err := p.Compile("now,-15m")
+		Expect(err).To(Succeed())
+		_, details, err := e.EvalExt(clock, time.UTC)
+		Expect(err).To(Succeed())
+		Expect(details.Trace.Steps).To(HaveLen(2))
+		Expect(details.Trace.Steps[0].After).To(Equal(now))
+	})
+
+	It("renders as text", func() {
+		p := epoch.NewTimeParser(epoch.WithIntervalArithmetics(), epoch.WithClock(clock), epoch.WithTrace())
+		_, details, err := p.ParseExt("today,-1d,/w", time.UTC)
+		Expect(err).To(Succeed())
+		Expect(details.Trace.String()).To(Equal(
+			"input:    today,-1d,/w\n" +
+				"location: UTC (argument)\n" +
+				"1. anchor    today  ->  2024-01-10T00:00:00Z (aliases)\n" +
+				"2. interval  -1d    2024-01-10T00:00:00Z  ->  2024-01-09T00:00:00Z\n" +
+				"3. rounding  /w     2024-01-09T00:00:00Z  ->  2024-01-08T00:00:00Z\n" +
+				"result:   2024-01-08T00:00:00Z\n"))
+	})
+
+	It("renders as JSON", func() {
+		p := epoch.NewTimeParser(epoch.WithIntervalArithmetics(), epoch.WithClock(clock), epoch.WithTrace())
+		_, details, err := p.ParseExt("today,+1h", time.UTC)
+		Expect(err).To(Succeed())
+
+		data, err := json.Marshal(details)
+		Expect(err).To(Succeed())
+		Expect(string(data)).To(ContainSubstring(`"trace":{"input":"today,+1h","location":"UTC","location_source":"argument","steps":[{"kind":"anchor","input":"today","after":"2024-01-10T00:00:00Z","parser":"aliases"},{"kind":"interval","input":"+1h","before":"2024-01-10T00:00:00Z","after":"2024-01-10T01:00:00Z"`))
+	})
+})
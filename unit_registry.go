@@ -0,0 +1,463 @@
+package epoch
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnitExists            = fmt.Errorf("unit already registered")
+	ErrInvalidUnitDefinition = fmt.Errorf("invalid unit definition")
+	ErrIncompatibleUnits     = fmt.Errorf("incompatible units")
+)
+
+// UnitDefinition describes a Unit and how it moves a time.Time
+//
+// A unit is either a fixed duration (Duration is set, e.g. "shift" = 8h)
+// or a calendar unit (AddFunc is set, e.g. "fy" = fiscal year).
+type UnitDefinition struct {
+	Unit Unit
+	// Aliases are alternative short spellings of the unit accepted on parsing
+	Aliases []string
+	// Duration is the precise length of a single unit. It's zero for calendar units
+	Duration time.Duration
+	// AddFunc moves t by the given amount of units. It's used for units without a fixed length
+	AddFunc func(t time.Time, value float64) time.Time
+	// TruncateFunc rounds t down to the start of the unit (e.g. start of the month).
+	// If it's not set, units with a fixed length are truncated to multiples of Duration since the zero time
+	TruncateFunc func(t time.Time) time.Time
+}
+
+// IsSafeDuration returns true if the unit has a precise length
+func (d UnitDefinition) IsSafeDuration() bool {
+	return d.AddFunc == nil && d.Duration != 0
+}
+
+// UnitRegistry is a set of units that can be extended with user-defined units.
+// It's safe for concurrent use.
+type UnitRegistry struct {
+	mu          sync.RWMutex
+	definitions []UnitDefinition
+	index       map[string]int
+}
+
+// defaultUnitRegistry holds built-in units only and is used by the package-level functions
+var defaultUnitRegistry = NewDefaultUnitRegistry()
+
+// NewUnitRegistry returns an empty registry
+func NewUnitRegistry() *UnitRegistry {
+	return &UnitRegistry{index: make(map[string]int)}
+}
+
+// NewDefaultUnitRegistry returns a registry pre-filled with all built-in units (see AvailableUnits)
+func NewDefaultUnitRegistry() *UnitRegistry {
+	r := NewUnitRegistry()
+	for _, def := range builtinUnitDefinitions() {
+		if err := r.Register(def); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+func builtinUnitDefinitions() []UnitDefinition {
+	addMonths := func(factor int) func(time.Time, float64) time.Time {
+		return func(t time.Time, value float64) time.Time {
+			return t.AddDate(0, int(value)*factor, 0)
+		}
+	}
+
+	clock := func(d time.Duration) func(time.Time) time.Time {
+		return func(t time.Time) time.Time {
+			return truncateClock(t, d)
+		}
+	}
+
+	microAliases := make([]string, 0, len(unitShortAliases))
+	for alias, canonical := range unitShortAliases {
+		if canonical == UnitMicrosecond.Short {
+			microAliases = append(microAliases, alias)
+		}
+	}
+
+	return []UnitDefinition{
+		{Unit: UnitNanosecond, Duration: time.Nanosecond, TruncateFunc: clock(time.Nanosecond)},
+		{Unit: UnitMicrosecond, Duration: time.Microsecond, Aliases: microAliases, TruncateFunc: clock(time.Microsecond)},
+		{Unit: UnitMillisecond, Duration: time.Millisecond, TruncateFunc: clock(time.Millisecond)},
+		{Unit: UnitSecond, Duration: time.Second, TruncateFunc: clock(time.Second)},
+		{Unit: UnitMinute, Duration: time.Minute, TruncateFunc: clock(time.Minute)},
+		{Unit: UnitHour, Duration: time.Hour, TruncateFunc: TruncateToHour},
+		{Unit: UnitDay, Duration: 24 * time.Hour, TruncateFunc: TruncateToDay},
+		{Unit: UnitWeek, Duration: 7 * 24 * time.Hour, TruncateFunc: TruncateToWeek},
+		BusinessDayUnitDefinition(nil),
+		{Unit: UnitMonth, AddFunc: addMonths(1), TruncateFunc: TruncateToMonth},
+		{Unit: UnitQuarter, AddFunc: addMonths(3), TruncateFunc: TruncateToQuarter},
+		{Unit: UnitYear, AddFunc: addMonths(12), TruncateFunc: TruncateToYear},
+	}
+}
+
+// Register adds a unit definition to the registry.
+// It fails if the short name (or any alias) is already taken.
+func (r *UnitRegistry) Register(def UnitDefinition) error {
+	if def.Unit.IsNil() {
+		return fmt.Errorf("%w: short name is empty", ErrInvalidUnitDefinition)
+	}
+	if def.Duration == 0 && def.AddFunc == nil {
+		return fmt.Errorf("%w: unit %s has neither duration nor add func", ErrInvalidUnitDefinition, def.Unit.Short)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := append([]string{def.Unit.Short}, def.Aliases...)
+	for _, name := range names {
+		if _, ok := r.index[name]; ok {
+			return fmt.Errorf("%w: %s", ErrUnitExists, name)
+		}
+	}
+
+	r.definitions = append(r.definitions, def)
+	for _, name := range names {
+		r.index[name] = len(r.definitions) - 1
+	}
+
+	return nil
+}
+
+// Replace replaces the definition of an already registered unit with the same short name,
+// e.g. to use a custom HolidayCalendar for business days (see BusinessDayUnitDefinition)
+func (r *UnitRegistry) Replace(def UnitDefinition) error {
+	if def.Duration == 0 && def.AddFunc == nil {
+		return fmt.Errorf("%w: unit %s has neither duration nor add func", ErrInvalidUnitDefinition, def.Unit.Short)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	idx, ok := r.index[def.Unit.Short]
+	if !ok || r.definitions[idx].Unit.Short != def.Unit.Short {
+		return fmt.Errorf("%w: unit %s is not registered", ErrInvalidUnitDefinition, def.Unit.Short)
+	}
+	for _, alias := range def.Aliases {
+		if other, ok := r.index[alias]; ok && other != idx {
+			return fmt.Errorf("%w: %s", ErrUnitExists, alias)
+		}
+	}
+
+	for _, alias := range r.definitions[idx].Aliases {
+		delete(r.index, alias)
+	}
+	for _, alias := range def.Aliases {
+		r.index[alias] = idx
+	}
+	r.definitions[idx] = def
+
+	return nil
+}
+
+// RegisterDuration registers a unit of a fixed length, e.g. "shift" = 8h
+func (r *UnitRegistry) RegisterDuration(short, full string, d time.Duration) (Unit, error) {
+	unit := Unit{Short: short, Full: full}
+	return unit, r.Register(UnitDefinition{Unit: unit, Duration: d})
+}
+
+// RegisterCalendar registers a unit without a fixed length, e.g. "fy" = fiscal year
+func (r *UnitRegistry) RegisterCalendar(short, full string, add func(t time.Time, value float64) time.Time) (Unit, error) {
+	unit := Unit{Short: short, Full: full}
+	return unit, r.Register(UnitDefinition{Unit: unit, AddFunc: add})
+}
+
+// Lookup returns a definition by its short name or alias
+func (r *UnitRegistry) Lookup(short string) (UnitDefinition, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	idx, ok := r.index[short]
+	if !ok {
+		return UnitDefinition{}, false
+	}
+	return r.definitions[idx], true
+}
+
+// Get returns a unit by its short name or alias. It returns a nil Unit if nothing is found
+func (r *UnitRegistry) Get(short string) Unit {
+	def, _ := r.Lookup(short)
+	return def.Unit
+}
+
+// Units returns all registered units in the order of registration
+func (r *UnitRegistry) Units() Units {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	units := make(Units, 0, len(r.definitions))
+	for _, def := range r.definitions {
+		units = append(units, def.Unit)
+	}
+	return units
+}
+
+// Clone returns an independent copy of the registry,
+// so it can be extended without affecting the original one
+func (r *UnitRegistry) Clone() *UnitRegistry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c := NewUnitRegistry()
+	c.definitions = append(c.definitions, r.definitions...)
+	for name, idx := range r.index {
+		c.index[name] = idx
+	}
+	return c
+}
+
+// ParseInterval parses an interval (see ParseInterval) using units of the registry.
+// It's small enough to be inlined, so the returned Interval doesn't escape to the heap in most cases.
+func (r *UnitRegistry) ParseInterval(interval string) (*Interval, error) {
+	i, err := r.scanInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+// scanInterval parses `[+-]digits[.digits][e[+-]digits][ ]unit` without allocations (on success)
+func (r *UnitRegistry) scanInterval(interval string) (Interval, error) {
+	s := strings.TrimSpace(interval)
+
+	n := 0
+	if n < len(s) && (s[n] == '-' || s[n] == '+') {
+		n++
+	}
+	digits, dot := 0, false
+	for ; n < len(s); n++ {
+		c := s[n]
+		if c >= '0' && c <= '9' {
+			digits++
+		} else if c == '.' && !dot {
+			dot = true
+		} else {
+			break
+		}
+	}
+	// the unit must follow the value, so "5.5.3m" or "m" are not accepted
+	if digits == 0 || n == len(s) || s[n] == '.' {
+		return Interval{}, fmt.Errorf("%w: %s", ErrInvalidFormat, interval)
+	}
+	// an exponent, e.g. "1e3s"
+	if s[n] == 'e' || s[n] == 'E' {
+		m := n + 1
+		if m < len(s) && (s[m] == '-' || s[m] == '+') {
+			m++
+		}
+		expDigits := 0
+		for ; m < len(s) && s[m] >= '0' && s[m] <= '9'; m++ {
+			expDigits++
+		}
+		if expDigits > 0 {
+			n = m
+		}
+	}
+
+	value, err := strconv.ParseFloat(s[:n], 64)
+	if err != nil {
+		return Interval{}, fmt.Errorf("%w: %s", ErrInvalidFormat, interval)
+	}
+
+	// spaces are allowed between the value and the unit, e.g. "5 m"
+	unitShort := strings.TrimLeft(s[n:], " \t")
+	if unitShort == "" {
+		return Interval{}, fmt.Errorf("%w: %s", ErrInvalidFormat, interval)
+	}
+	unit := r.Get(unitShort)
+	if unit.IsNil() {
+		return Interval{}, ErrInvalidUnit
+	}
+
+	return Interval{Value: value, Unit: unit}, nil
+}
+
+// Duration returns the precise duration of the interval.
+// The second value is false if the interval's unit has no fixed length or is unknown.
+func (r *UnitRegistry) Duration(i *Interval) (time.Duration, bool) {
+	def, ok := r.Lookup(i.Unit.Short)
+	if !ok || !def.IsSafeDuration() {
+		return 0, false
+	}
+	return time.Duration(i.Value * float64(def.Duration)), true
+}
+
+// monthsIn is the number of months in calendar units that are multiples of months
+var monthsIn = map[string]float64{
+	UnitMonth.Short:   1,
+	UnitQuarter.Short: 3,
+	UnitYear.Short:    12,
+}
+
+// normalUnits are units Normalize chooses from, the longest first
+var normalUnits = [][]Unit{
+	{UnitWeek, UnitDay, UnitHour, UnitMinute, UnitSecond, UnitMillisecond, UnitMicrosecond, UnitNanosecond},
+	{UnitYear, UnitQuarter, UnitMonth},
+}
+
+// Convert expresses the interval in the given unit, e.g. 90m in hours is 1.5h.
+// Units with a fixed length are converted into each other, so are months, quarters and years;
+// ErrIncompatibleUnits is returned for other units (e.g. months into days).
+func (r *UnitRegistry) Convert(i *Interval, u Unit) (*Interval, error) {
+	if def, ok := r.Lookup(u.Short); ok {
+		u = def.Unit
+	}
+
+	if d, ok := r.Duration(i); ok {
+		if target, ok := r.Duration(&Interval{Value: 1, Unit: u}); ok {
+			return &Interval{Value: float64(d) / float64(target), Unit: u}, nil
+		}
+	}
+	if from, ok := monthsIn[i.Unit.Short]; ok {
+		if to, ok := monthsIn[u.Short]; ok {
+			return &Interval{Value: i.Value * from / to, Unit: u}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s can't be converted into %s", ErrIncompatibleUnits, i, u.Full)
+}
+
+// Normalize expresses the interval in the longest built-in unit giving a whole number,
+// e.g. 120m is 2h, 14d is 2w, 12mo is 1y. Other intervals are returned as they are.
+func (r *UnitRegistry) Normalize(i *Interval) *Interval {
+	for _, units := range normalUnits {
+		for _, u := range units {
+			converted, err := r.Convert(i, u)
+			if err == nil && converted.Value == math.Trunc(converted.Value) && converted.Value != 0 {
+				return converted
+			}
+		}
+	}
+
+	normalized := *i
+	return &normalized
+}
+
+// AddInterval adds the given interval to the given time (see TimeAddInterval).
+// Units that are unknown to the registry are handled the same way as by TimeAddInterval.
+func (r *UnitRegistry) AddInterval(t time.Time, i *Interval) time.Time {
+	def, ok := r.Lookup(i.Unit.Short)
+	if !ok {
+		if i.IsSafeDuration() {
+			return t.Add(i.Duration())
+		}
+		return t.AddDate(i.ExtractDateParts())
+	}
+
+	if def.AddFunc != nil {
+		return def.AddFunc(t, i.Value)
+	}
+	return t.Add(time.Duration(i.Value * float64(def.Duration)))
+}
+
+// Truncate rounds t down to the start of the given unit (see UnitDefinition.TruncateFunc).
+// The second value is false if the unit is unknown or can't be truncated.
+func (r *UnitRegistry) Truncate(t time.Time, u Unit) (time.Time, bool) {
+	def, ok := r.Lookup(u.Short)
+	switch {
+	case !ok:
+		return t, false
+	case def.TruncateFunc != nil:
+		return def.TruncateFunc(t), true
+	case def.IsSafeDuration():
+		return t.Truncate(def.Duration), true
+	default:
+		return t, false
+	}
+}
+
+// TruncateToInterval rounds t down to the closest boundary aligned to the interval on the wall clock:
+// multiples of fixed units since the midnight (e.g. 15m -> :00, :15, :30 and :45),
+// multiples of days since the start of the month, of months and quarters since the start of the year,
+// of years since the year zero and of weeks since Monday, January 5, 1970.
+// Sequences are reset at the start of each period (e.g. 7m gives :56 and then :00 of the next hour).
+//
+// Intervals of a single unit are truncated as by Truncate. The second value is false if the interval can't be aligned
+// (it's not positive, or several user-defined calendar units are given).
+func (r *UnitRegistry) TruncateToInterval(t time.Time, i *Interval) (time.Time, bool) {
+	if i.Value == 1 {
+		return r.Truncate(t, i.Unit)
+	}
+	if i.Value <= 0 {
+		return t, false
+	}
+
+	// fixed units shorter than a day are aligned on the wall clock (fractional amounts are allowed)
+	if d, ok := r.Duration(i); ok && d > 0 && d < 24*time.Hour {
+		return truncateClock(t, d), true
+	}
+
+	n := int(i.Value)
+	if float64(n) != i.Value {
+		return t, false
+	}
+
+	y, m, d := t.Date()
+	switch i.Unit {
+	case UnitDay:
+		return time.Date(y, m, 1+(d-1)/n*n, 0, 0, 0, 0, t.Location()), true
+	case UnitWeek:
+		w := TruncateToWeek(t)
+		days := int(time.Date(w.Year(), w.Month(), w.Day(), 0, 0, 0, 0, time.UTC).Sub(weeksEpoch).Hours() / 24)
+		return w.AddDate(0, 0, -7*floorMod(days/7, n)), true
+	case UnitMonth:
+		return time.Date(y, time.Month(1+(int(m)-1)/n*n), 1, 0, 0, 0, 0, t.Location()), true
+	case UnitQuarter:
+		return time.Date(y, time.Month(1+(int(m)-1)/(3*n)*(3*n)), 1, 0, 0, 0, 0, t.Location()), true
+	case UnitYear:
+		return time.Date(y-floorMod(y, n), time.January, 1, 0, 0, 0, 0, t.Location()), true
+	}
+
+	return t, false
+}
+
+// comparisonReference is the time intervals of calendar units are compared at
+var comparisonReference = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// longer checks if interval a is longer than b when both are added to the same time (nil b stands for zero)
+func (r *UnitRegistry) longer(a, b *Interval) bool {
+	than := comparisonReference
+	if b != nil {
+		than = r.AddInterval(comparisonReference, b)
+	}
+	return r.AddInterval(comparisonReference, a).After(than)
+}
+
+// weeksEpoch is the Monday multiples of weeks are counted from
+var weeksEpoch = time.Date(1970, time.January, 5, 0, 0, 0, 0, time.UTC)
+
+func floorMod(a, b int) int {
+	return ((a % b) + b) % b
+}
+
+// Humanize returns a human-readable form of the interval, e.g. "2 sprints" or "1.5 hours",
+// using the full name of the unit known to the registry
+func (r *UnitRegistry) Humanize(i *Interval) string {
+	unit := i.Unit
+	if def, ok := r.Lookup(i.Unit.Short); ok {
+		unit = def.Unit
+	}
+
+	return humanizeValue(i.Value, unit)
+}
+
+func humanizeValue(value float64, unit Unit) string {
+	name := unit.Full
+	if name == "" {
+		name = unit.Short
+	}
+	if value != 1 && value != -1 {
+		name += "s"
+	}
+
+	return strconv.FormatFloat(value, 'f', -1, 64) + " " + name
+}
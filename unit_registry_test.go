@@ -0,0 +1,119 @@
+package epoch_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aahainc/epoch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UnitRegistry", func() {
+	var r *epoch.UnitRegistry
+
+	// fiscal year is a calendar unit moving the date by whole years
+	fiscalYear := func(t time.Time, value float64) time.Time {
+		return t.AddDate(int(value), 0, 0)
+	}
+
+	BeforeEach(func() {
+		r = epoch.NewDefaultUnitRegistry()
+	})
+
+	It("contains all built-in units", func() {
+		Expect(r.Units()).To(Equal(epoch.AvailableUnits))
+	})
+
+	Context("user-defined units", func() {
+		BeforeEach(func() {
+			_, err := r.RegisterDuration("sprint", "sprint", 2*7*24*time.Hour)
+			Expect(err).Should(Succeed())
+			_, err = r.RegisterDuration("shift", "shift", 8*time.Hour)
+			Expect(err).Should(Succeed())
+			_, err = r.RegisterCalendar("fy", "fiscal year", fiscalYear)
+			Expect(err).Should(Succeed())
+		})
+
+		DescribeTable("parses intervals", func(input string, expectedVal float64, expectedShort string) {
+			i, err := r.ParseInterval(input)
+			Expect(err).Should(Succeed())
+			Expect(i.Value).To(Equal(expectedVal))
+			Expect(i.Unit.Short).To(Equal(expectedShort))
+		},
+			Entry("sprints", "2sprint", 2.0, "sprint"),
+			Entry("shifts", "-1shift", -1.0, "shift"),
+			Entry("fiscal years", "1fy", 1.0, "fy"),
+			Entry("built-in units are still available", "5m", 5.0, "m"),
+		)
+
+		It("adds fixed duration units", func() {
+			t := time.Date(2023, time.January, 2, 9, 0, 0, 0, time.UTC)
+			Expect(r.AddInterval(t, &epoch.Interval{Value: 1, Unit: r.Get("sprint")}).String()).To(Equal("2023-01-16 09:00:00 +0000 UTC"))
+			d, ok := r.Duration(&epoch.Interval{Value: 1.5, Unit: r.Get("shift")})
+			Expect(ok).To(BeTrue())
+			Expect(d).To(Equal(12 * time.Hour))
+		})
+
+		It("adds calendar units", func() {
+			t := time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)
+			i := epoch.Interval{Value: 2, Unit: r.Get("fy")}
+			Expect(r.AddInterval(t, &i).String()).To(Equal("2025-04-01 00:00:00 +0000 UTC"))
+			_, ok := r.Duration(&i)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("humanizes intervals", func() {
+			Expect(r.Humanize(&epoch.Interval{Value: 2, Unit: r.Get("sprint")})).To(Equal("2 sprints"))
+			Expect(r.Humanize(&epoch.Interval{Value: 1, Unit: r.Get("fy")})).To(Equal("1 fiscal year"))
+			Expect(r.Humanize(epoch.MustParseInterval("1.5h"))).To(Equal("1.5 hours"))
+		})
+
+		It("doesn't leak into the built-in units", func() {
+			_, err := epoch.ParseInterval("2sprint")
+			Expect(errors.Is(err, epoch.ErrInvalidUnit)).To(BeTrue())
+		})
+
+		It("doesn't leak into the cloned registry", func() {
+			c := r.Clone()
+			_, err := c.RegisterDuration("sol", "sol", 24*time.Hour+39*time.Minute)
+			Expect(err).Should(Succeed())
+			Expect(c.Get("sprint").IsNil()).To(BeFalse())
+			Expect(r.Get("sol").IsNil()).To(BeTrue())
+		})
+	})
+
+	Context("registering invalid units", func() {
+		It("rejects duplicates", func() {
+			_, err := r.RegisterDuration("m", "meter", time.Minute)
+			Expect(errors.Is(err, epoch.ErrUnitExists)).To(BeTrue())
+		})
+		It("rejects units without length", func() {
+			err := r.Register(epoch.UnitDefinition{Unit: epoch.Unit{Short: "x", Full: "x"}})
+			Expect(errors.Is(err, epoch.ErrInvalidUnitDefinition)).To(BeTrue())
+		})
+		It("rejects units without short name", func() {
+			_, err := r.RegisterDuration("", "nothing", time.Minute)
+			Expect(errors.Is(err, epoch.ErrInvalidUnitDefinition)).To(BeTrue())
+		})
+	})
+
+	Context("TimeParser", func() {
+		fixedNow := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+
+		It("honours user-defined units per parser", func() {
+			_, err := r.RegisterDuration("shift", "shift", 8*time.Hour)
+			Expect(err).Should(Succeed())
+
+			parsers := epoch.WithParsers(epoch.NewAliasesParser().SetClock(epoch.NewStaticClock(fixedNow)))
+			p := epoch.NewTimeParser(parsers, epoch.WithIntervalArithmetics(), epoch.WithUnits(r))
+			t, err := p.Parse("today,1shift", time.UTC)
+			Expect(err).Should(Succeed())
+			Expect(t).To(Equal(time.Date(2006, time.January, 2, 8, 0, 0, 0, time.UTC)))
+
+			other := epoch.NewTimeParser(parsers, epoch.WithIntervalArithmetics())
+			_, err = other.Parse("today,1shift", time.UTC)
+			Expect(errors.Is(err, epoch.ErrInvalidUnit)).To(BeTrue())
+		})
+	})
+})
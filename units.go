@@ -7,22 +7,35 @@ type Unit struct {
 
 type Units []Unit
 
+var UnitNanosecond = Unit{"ns", "nanosecond"}
+var UnitMicrosecond = Unit{"us", "microsecond"}
+var UnitMillisecond = Unit{"ms", "millisecond"}
 var UnitSecond = Unit{"s", "second"}
 var UnitMinute = Unit{"m", "minute"}
 var UnitHour = Unit{"h", "hour"}
 var UnitDay = Unit{"d", "day"}
 var UnitWeek = Unit{"w", "week"}
+var UnitBusinessDay = Unit{"bd", "business day"}
 var UnitMonth = Unit{"mo", "month"}
 var UnitQuarter = Unit{"q", "quarter"}
 var UnitYear = Unit{"y", "year"}
 
-var AvailableUnits = Units{UnitSecond, UnitMinute, UnitHour, UnitDay, UnitWeek, UnitMonth, UnitQuarter, UnitYear}
+var AvailableUnits = Units{UnitNanosecond, UnitMicrosecond, UnitMillisecond, UnitSecond, UnitMinute, UnitHour, UnitDay, UnitWeek, UnitBusinessDay, UnitMonth, UnitQuarter, UnitYear}
+
+// unitShortAliases maps alternative spellings of short units to their canonical short form
+var unitShortAliases = map[string]string{
+	"µs": "us", // micro sign (U+00B5)
+	"μs": "us", // greek small letter mu (U+03BC)
+}
 
 func (unit Unit) IsNil() bool {
 	return unit.Short == ""
 }
 
 func (units Units) Get(s string) Unit {
+	if canonical, ok := unitShortAliases[s]; ok {
+		s = canonical
+	}
 	for _, u := range units {
 		if u.Short == s {
 			return u
@@ -43,11 +56,14 @@ type UnitFactory struct {
 	units Units
 }
 
-func (f *UnitFactory) Second() Unit  { return f.units.Get("s") }
-func (f *UnitFactory) Minute() Unit  { return f.units.Get("m") }
-func (f *UnitFactory) Hour() Unit    { return f.units.Get("h") }
-func (f *UnitFactory) Day() Unit     { return f.units.Get("d") }
-func (f *UnitFactory) Week() Unit    { return f.units.Get("w") }
-func (f *UnitFactory) Month() Unit   { return f.units.Get("mo") }
-func (f *UnitFactory) Quarter() Unit { return f.units.Get("q") }
-func (f *UnitFactory) Year() Unit    { return f.units.Get("y") }
+func (f *UnitFactory) Nanosecond() Unit  { return f.units.Get("ns") }
+func (f *UnitFactory) Microsecond() Unit { return f.units.Get("us") }
+func (f *UnitFactory) Millisecond() Unit { return f.units.Get("ms") }
+func (f *UnitFactory) Second() Unit      { return f.units.Get("s") }
+func (f *UnitFactory) Minute() Unit      { return f.units.Get("m") }
+func (f *UnitFactory) Hour() Unit        { return f.units.Get("h") }
+func (f *UnitFactory) Day() Unit         { return f.units.Get("d") }
+func (f *UnitFactory) Week() Unit        { return f.units.Get("w") }
+func (f *UnitFactory) Month() Unit       { return f.units.Get("mo") }
+func (f *UnitFactory) Quarter() Unit     { return f.units.Get("q") }
+func (f *UnitFactory) Year() Unit        { return f.units.Get("y") }
@@ -0,0 +1,322 @@
+package epoch
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidWorkingWindow = fmt.Errorf("invalid working window")
+	ErrNoWorkingHours       = fmt.Errorf("no working hours")
+)
+
+// WorkingWindow is a period of a day when work happens, e.g. 09:00-17:00.
+// Start and End are offsets from the midnight on the wall clock, End is exclusive.
+type WorkingWindow struct {
+	Start time.Duration `json:"start"`
+	End   time.Duration `json:"end"`
+}
+
+// NewWorkingWindow returns a window between the given wall clock times (hour, minute)
+func NewWorkingWindow(startHour, startMinute, endHour, endMinute int) WorkingWindow {
+	return WorkingWindow{
+		Start: time.Duration(startHour)*time.Hour + time.Duration(startMinute)*time.Minute,
+		End:   time.Duration(endHour)*time.Hour + time.Duration(endMinute)*time.Minute,
+	}
+}
+
+// ParseWorkingWindow parses a window in the format of `HH:MM-HH:MM`, e.g. "09:00-17:00".
+// "24:00" is allowed as the end of a window.
+func ParseWorkingWindow(s string) (WorkingWindow, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return WorkingWindow{}, fmt.Errorf("%w: %s", ErrInvalidWorkingWindow, s)
+	}
+
+	start, err := parseClockOffset(parts[0])
+	if err != nil {
+		return WorkingWindow{}, fmt.Errorf("%w: %s", ErrInvalidWorkingWindow, s)
+	}
+	end, err := parseClockOffset(parts[1])
+	if err != nil {
+		return WorkingWindow{}, fmt.Errorf("%w: %s", ErrInvalidWorkingWindow, s)
+	}
+
+	w := WorkingWindow{Start: start, End: end}
+	if !w.IsValid() {
+		return WorkingWindow{}, fmt.Errorf("%w: %s", ErrInvalidWorkingWindow, s)
+	}
+	return w, nil
+}
+
+// MustParseWorkingWindow is like ParseWorkingWindow but panics on error
+func MustParseWorkingWindow(s string) WorkingWindow {
+	w, err := ParseWorkingWindow(s)
+	if err != nil {
+		panic(err)
+	}
+	return w
+}
+
+func parseClockOffset(s string) (time.Duration, error) {
+	hm := strings.Split(strings.TrimSpace(s), ":")
+	if len(hm) != 2 {
+		return 0, ErrInvalidFormat
+	}
+
+	h, err := strconv.Atoi(hm[0])
+	if err != nil {
+		return 0, err
+	}
+	m, err := strconv.Atoi(hm[1])
+	if err != nil || m < 0 || m > 59 {
+		return 0, ErrInvalidFormat
+	}
+
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// IsValid checks that the window is not empty and fits into a day
+func (w WorkingWindow) IsValid() bool {
+	return w.Start >= 0 && w.Start < w.End && w.End <= 24*time.Hour
+}
+
+func (w WorkingWindow) String() string {
+	format := func(d time.Duration) string {
+		return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return format(w.Start) + "-" + format(w.End)
+}
+
+// WorkingHours is a weekly schedule of working windows in a timezone, e.g. Mon–Fri 09:00–17:00 in America/New_York.
+// Exceptions override the schedule for specific dates and holidays of a HolidayCalendar are non-working days.
+//
+// It's not safe to modify it concurrently with reading.
+type WorkingHours struct {
+	loc        *time.Location
+	week       [7][]WorkingWindow
+	exceptions map[civilDate][]WorkingWindow
+	holidays   HolidayCalendar
+}
+
+// NewWorkingHours returns an empty schedule (no working hours at all) in the given location.
+// If nil location is given, UTC is used.
+func NewWorkingHours(loc *time.Location) *WorkingHours {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &WorkingHours{
+		loc:        loc,
+		exceptions: make(map[civilDate][]WorkingWindow),
+	}
+}
+
+// Location returns the location of the schedule
+func (w *WorkingHours) Location() *time.Location {
+	return w.loc
+}
+
+// SetDay replaces working windows of the given weekday. No windows means a day off
+func (w *WorkingHours) SetDay(day time.Weekday, windows ...WorkingWindow) *WorkingHours {
+	w.week[day] = normalizeWorkingWindows(windows)
+	return w
+}
+
+// SetWeekdays sets the same working windows for Monday to Friday
+func (w *WorkingHours) SetWeekdays(windows ...WorkingWindow) *WorkingHours {
+	for day := time.Monday; day <= time.Friday; day++ {
+		w.SetDay(day, windows...)
+	}
+	return w
+}
+
+// AddException overrides working windows on the day of date (its year, month and day are used as is).
+// No windows means a day off.
+func (w *WorkingHours) AddException(date time.Time, windows ...WorkingWindow) *WorkingHours {
+	w.exceptions[civilDateOf(date)] = normalizeWorkingWindows(windows)
+	return w
+}
+
+// SetHolidayCalendar makes all non-business days of the calendar days off (exceptions still take precedence)
+func (w *WorkingHours) SetHolidayCalendar(cal HolidayCalendar) *WorkingHours {
+	w.holidays = cal
+	return w
+}
+
+// normalizeWorkingWindows drops invalid windows, sorts and merges overlapping ones
+func normalizeWorkingWindows(windows []WorkingWindow) []WorkingWindow {
+	result := make([]WorkingWindow, 0, len(windows))
+	for _, win := range windows {
+		if win.IsValid() {
+			result = append(result, win)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Start < result[j].Start
+	})
+
+	merged := result[:0]
+	for _, win := range result {
+		if n := len(merged); n > 0 && win.Start <= merged[n-1].End {
+			if win.End > merged[n-1].End {
+				merged[n-1].End = win.End
+			}
+			continue
+		}
+		merged = append(merged, win)
+	}
+	return merged
+}
+
+// workingPeriod is a working window placed on a specific day
+type workingPeriod struct {
+	start, end time.Time
+}
+
+// periodsOn returns working periods on the given day (midnight in w.loc)
+func (w *WorkingHours) periodsOn(day time.Time) []workingPeriod {
+	windows, ok := w.exceptions[civilDateOf(day)]
+	if !ok {
+		if w.holidays != nil && !w.holidays.IsBusinessDay(day) {
+			return nil
+		}
+		windows = w.week[day.Weekday()]
+	}
+
+	periods := make([]workingPeriod, 0, len(windows))
+	y, m, d := day.Date()
+	for _, win := range windows {
+		periods = append(periods, workingPeriod{
+			// time.Date normalizes nanoseconds into the wall clock, so DST days are handled properly
+			start: time.Date(y, m, d, 0, 0, 0, int(win.Start), w.loc),
+			end:   time.Date(y, m, d, 0, 0, 0, int(win.End), w.loc),
+		})
+	}
+	return periods
+}
+
+func (w *WorkingHours) hasWorkingDays() bool {
+	for _, windows := range w.week {
+		if len(windows) > 0 {
+			return true
+		}
+	}
+	for _, windows := range w.exceptions {
+		if len(windows) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsWithin checks if t is within working hours
+func (w *WorkingHours) IsWithin(t time.Time) bool {
+	for _, p := range w.periodsOn(TruncateToDay(t.In(w.loc))) {
+		if !t.Before(p.start) && t.Before(p.end) {
+			return true
+		}
+	}
+	return false
+}
+
+// BusinessDuration returns the amount of working time between from and to.
+// It's negative if to is before from.
+func (w *WorkingHours) BusinessDuration(from, to time.Time) time.Duration {
+	if to.Before(from) {
+		return -w.BusinessDuration(to, from)
+	}
+
+	var total time.Duration
+	last := TruncateToDay(to.In(w.loc))
+	for day := TruncateToDay(from.In(w.loc)); !day.After(last); day = day.AddDate(0, 0, 1) {
+		for _, p := range w.periodsOn(day) {
+			start, end := p.start, p.end
+			if start.Before(from) {
+				start = from
+			}
+			if end.After(to) {
+				end = to
+			}
+			if end.After(start) {
+				total += end.Sub(start)
+			}
+		}
+	}
+	return total
+}
+
+// Add moves t by d of working time, e.g. adding 2h to Friday 16:00 gives Monday 10:00 for Mon–Fri 09:00–17:00.
+// Negative d moves t backwards. The result is in t's location.
+// ErrNoWorkingHours is returned if the schedule has no working time to move through.
+func (w *WorkingHours) Add(t time.Time, d time.Duration) (time.Time, error) {
+	if d == 0 {
+		return t, nil
+	}
+	if !w.hasWorkingDays() {
+		return time.Time{}, ErrNoWorkingHours
+	}
+
+	if d > 0 {
+		return w.addForward(t, d)
+	}
+	return w.addBackward(t, -d)
+}
+
+func (w *WorkingHours) addForward(t time.Time, d time.Duration) (time.Time, error) {
+	loc := t.Location()
+	day := TruncateToDay(t.In(w.loc))
+	for idle := 0; idle < maxNonBusinessDays; day = day.AddDate(0, 0, 1) {
+		idle++
+		for _, p := range w.periodsOn(day) {
+			if !p.end.After(t) {
+				continue
+			}
+			start := p.start
+			if start.Before(t) {
+				start = t
+			}
+
+			available := p.end.Sub(start)
+			if d <= available {
+				return start.Add(d).In(loc), nil
+			}
+			d -= available
+			t = p.end
+			idle = 0
+		}
+	}
+
+	return time.Time{}, ErrNoWorkingHours
+}
+
+func (w *WorkingHours) addBackward(t time.Time, d time.Duration) (time.Time, error) {
+	loc := t.Location()
+	day := TruncateToDay(t.In(w.loc))
+	for idle := 0; idle < maxNonBusinessDays; day = day.AddDate(0, 0, -1) {
+		idle++
+		periods := w.periodsOn(day)
+		for i := len(periods) - 1; i >= 0; i-- {
+			p := periods[i]
+			if !p.start.Before(t) {
+				continue
+			}
+			end := p.end
+			if end.After(t) {
+				end = t
+			}
+
+			available := end.Sub(p.start)
+			if d <= available {
+				return end.Add(-d).In(loc), nil
+			}
+			d -= available
+			t = p.start
+			idle = 0
+		}
+	}
+
+	return time.Time{}, ErrNoWorkingHours
+}
@@ -0,0 +1,114 @@
+package epoch_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aahainc/epoch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkingHours", func() {
+	loc, _ := time.LoadLocation("America/New_York")
+	at := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2023, month, day, hour, min, 0, 0, loc)
+	}
+
+	var wh *epoch.WorkingHours
+	BeforeEach(func() {
+		// Mon–Fri 09:00–17:00, 2023-12-22 is Friday
+		wh = epoch.NewWorkingHours(loc).
+			SetWeekdays(epoch.MustParseWorkingWindow("09:00-17:00")).
+			AddException(time.Date(2023, time.December, 25, 0, 0, 0, 0, time.UTC)).
+			AddException(time.Date(2023, time.December, 24, 0, 0, 0, 0, time.UTC), epoch.MustParseWorkingWindow("10:00-12:00"))
+	})
+
+	Context("ParseWorkingWindow", func() {
+		It("parses valid windows", func() {
+			w, err := epoch.ParseWorkingWindow("09:30-24:00")
+			Expect(err).Should(Succeed())
+			Expect(w).To(Equal(epoch.NewWorkingWindow(9, 30, 24, 0)))
+			Expect(w.String()).To(Equal("09:30-24:00"))
+		})
+
+		DescribeTable("rejects invalid windows", func(input string) {
+			_, err := epoch.ParseWorkingWindow(input)
+			Expect(errors.Is(err, epoch.ErrInvalidWorkingWindow)).To(BeTrue())
+		},
+			Entry("empty", ""),
+			Entry("reversed", "17:00-09:00"),
+			Entry("too long", "09:00-25:00"),
+			Entry("bad minutes", "09:75-10:00"),
+		)
+	})
+
+	Context("IsWithin", func() {
+		DescribeTable("checks instants", func(t time.Time, expected bool) {
+			Expect(wh.IsWithin(t)).To(Equal(expected))
+		},
+			Entry("during the day", at(time.December, 22, 10, 0), true),
+			Entry("at the start", at(time.December, 22, 9, 0), true),
+			Entry("at the end (exclusive)", at(time.December, 22, 17, 0), false),
+			Entry("on a weekend", at(time.December, 23, 10, 0), false),
+			Entry("on a day off exception", at(time.December, 25, 10, 0), false),
+			Entry("on a working exception", at(time.December, 24, 11, 0), true),
+			Entry("in another timezone", time.Date(2023, time.December, 22, 15, 0, 0, 0, time.UTC), true),
+		)
+	})
+
+	Context("BusinessDuration", func() {
+		It("counts working time only", func() {
+			Expect(wh.BusinessDuration(at(time.December, 21, 16, 0), at(time.December, 22, 10, 0))).To(Equal(2 * time.Hour))
+			Expect(wh.BusinessDuration(at(time.December, 22, 8, 0), at(time.December, 26, 18, 0))).To(Equal(18 * time.Hour))
+			Expect(wh.BusinessDuration(at(time.December, 22, 10, 0), at(time.December, 21, 16, 0))).To(Equal(-2 * time.Hour))
+		})
+
+		It("handles DST days", func() {
+			weekend := epoch.NewWorkingHours(loc).SetDay(time.Sunday, epoch.MustParseWorkingWindow("00:00-24:00"))
+			day := time.Date(2023, time.March, 12, 0, 0, 0, 0, loc)
+			Expect(weekend.BusinessDuration(day, day.AddDate(0, 0, 1))).To(Equal(23 * time.Hour))
+		})
+	})
+
+	Context("Add", func() {
+		DescribeTable("moves time by working hours", func(t time.Time, d time.Duration, expected time.Time) {
+			result, err := wh.Add(t, d)
+			Expect(err).Should(Succeed())
+			Expect(result).To(BeTemporally("==", expected))
+		},
+			Entry("within a day", at(time.December, 21, 10, 0), 2*time.Hour, at(time.December, 21, 12, 0)),
+			Entry("to the end of a day", at(time.December, 21, 10, 0), 7*time.Hour, at(time.December, 21, 17, 0)),
+			Entry("over a weekend", at(time.December, 22, 16, 0), 2*time.Hour, at(time.December, 24, 11, 0)),
+			Entry("over exceptions", at(time.December, 22, 16, 0), 6*time.Hour, at(time.December, 26, 12, 0)),
+			Entry("from outside hours", at(time.December, 21, 20, 0), time.Hour, at(time.December, 22, 10, 0)),
+			Entry("backwards", at(time.December, 26, 10, 0), -2*time.Hour, at(time.December, 24, 11, 0)),
+			Entry("backwards over a weekend", at(time.December, 26, 10, 0), -4*time.Hour, at(time.December, 22, 16, 0)),
+		)
+
+		It("keeps the location of the given time", func() {
+			t := time.Date(2023, time.December, 21, 15, 0, 0, 0, time.UTC) // 10:00 in New York
+			for _, d := range []time.Duration{time.Hour, 9 * time.Hour, -time.Hour, -9 * time.Hour} {
+				result, err := wh.Add(t, d)
+				Expect(err).Should(Succeed())
+				Expect(result.Location()).To(Equal(time.UTC), d.String())
+			}
+
+			result, _ := wh.Add(t, 9*time.Hour)
+			Expect(result).To(Equal(time.Date(2023, time.December, 22, 16, 0, 0, 0, time.UTC)))
+		})
+
+		It("fails without working hours", func() {
+			_, err := epoch.NewWorkingHours(loc).Add(at(time.December, 22, 10, 0), time.Hour)
+			Expect(errors.Is(err, epoch.ErrNoWorkingHours)).To(BeTrue())
+		})
+
+		It("skips holidays of a calendar", func() {
+			cal := epoch.NewStaticHolidayCalendar().AddHoliday(time.Date(2023, time.December, 26, 0, 0, 0, 0, time.UTC), "Boxing Day")
+			wh.SetHolidayCalendar(cal)
+			result, err := wh.Add(at(time.December, 24, 11, 0), 2*time.Hour)
+			Expect(err).Should(Succeed())
+			Expect(result).To(BeTemporally("==", at(time.December, 27, 10, 0)))
+		})
+	})
+})